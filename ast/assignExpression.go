@@ -0,0 +1,62 @@
+package ast
+
+import (
+	"bytes"
+
+	"com.language/monkey/token"
+)
+
+// x = <expression>
+// x += <expression>
+type AssignExpression struct {
+	Token token.Token // = or a compound operator like +=
+	Name  *Identifier
+	// the arithmetic of a compound assignment, "+" for +=, empty for =
+	Operator string
+	Value    Expression
+}
+
+func (ae *AssignExpression) expressionNode() {}
+
+func (ae *AssignExpression) TokenLiteral() string {
+	return ae.Token.Literal
+}
+
+func (ae *AssignExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ae.Name.String())
+	out.WriteString(" " + ae.Operator + "= ")
+	out.WriteString(ae.Value.String())
+	out.WriteString(")")
+
+	return out.String()
+}
+
+// obj.field = <expression>
+// obj.field += <expression>
+type MemberAssignExpression struct {
+	Token    token.Token
+	Target   *MemberExpression
+	Operator string
+	Value    Expression
+}
+
+func (ma *MemberAssignExpression) expressionNode() {}
+
+func (ma *MemberAssignExpression) TokenLiteral() string {
+	return ma.Token.Literal
+}
+
+func (ma *MemberAssignExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ma.Target.Object.String() + "." + ma.Target.Property.String())
+	out.WriteString(" " + ma.Operator + "= ")
+	out.WriteString(ma.Value.String())
+	out.WriteString(")")
+
+	return out.String()
+}
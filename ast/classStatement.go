@@ -0,0 +1,46 @@
+package ast
+
+import (
+	"bytes"
+
+	"com.language/monkey/token"
+)
+
+/*
+	class Name < Super {
+		init(params) { ... }
+		method(params) { ... }
+	}
+*/
+type ClassStatement struct {
+	Token token.Token // class
+	Name  *Identifier
+	// nil without a superclass
+	Super *Identifier
+	// named function literals, in declaration order
+	Methods []*FunctionLiteral
+}
+
+func (cs *ClassStatement) statementNode() {}
+
+func (cs *ClassStatement) TokenLiteral() string {
+	return cs.Token.Literal
+}
+
+func (cs *ClassStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("class ")
+	out.WriteString(cs.Name.String())
+	if cs.Super != nil {
+		out.WriteString(" < " + cs.Super.String())
+	}
+	out.WriteString(" { ")
+	for _, method := range cs.Methods {
+		out.WriteString(method.String())
+		out.WriteString(" ")
+	}
+	out.WriteString("}")
+
+	return out.String()
+}
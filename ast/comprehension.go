@@ -0,0 +1,80 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"com.language/monkey/token"
+)
+
+// for x in xs if x > 1
+type ComprehensionClause struct {
+	Token      token.Token
+	Variables  []*Identifier
+	Iterable   Expression
+	Conditions []Expression
+}
+
+func (cc *ComprehensionClause) String() string {
+	var out bytes.Buffer
+
+	vars := []string{}
+	for _, variable := range cc.Variables {
+		vars = append(vars, variable.String())
+	}
+
+	out.WriteString("for ")
+	out.WriteString(strings.Join(vars, ", "))
+	out.WriteString(" in ")
+	out.WriteString(cc.Iterable.String())
+	for _, cond := range cc.Conditions {
+		out.WriteString(" if ")
+		out.WriteString(cond.String())
+	}
+
+	return out.String()
+}
+
+func clausesString(clauses []*ComprehensionClause) string {
+	strs := []string{}
+	for _, clause := range clauses {
+		strs = append(strs, clause.String())
+	}
+	return strings.Join(strs, " ")
+}
+
+// [x * 2 for x in xs if x > 1]
+type ArrayComprehension struct {
+	Token   token.Token // [
+	Element Expression
+	// the first clause is the outermost loop
+	Clauses []*ComprehensionClause
+}
+
+func (ac *ArrayComprehension) expressionNode() {}
+
+func (ac *ArrayComprehension) TokenLiteral() string {
+	return ac.Token.Literal
+}
+
+func (ac *ArrayComprehension) String() string {
+	return "[" + ac.Element.String() + " " + clausesString(ac.Clauses) + "]"
+}
+
+// {k: v for k, v in h}
+type HashComprehension struct {
+	Token   token.Token // {
+	Key     Expression
+	Value   Expression
+	Clauses []*ComprehensionClause
+}
+
+func (hc *HashComprehension) expressionNode() {}
+
+func (hc *HashComprehension) TokenLiteral() string {
+	return hc.Token.Literal
+}
+
+func (hc *HashComprehension) String() string {
+	return "{" + hc.Key.String() + ":" + hc.Value.String() + " " + clausesString(hc.Clauses) + "}"
+}
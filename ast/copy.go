@@ -0,0 +1,305 @@
+package ast
+
+// Copy returns a deep copy of node, so the copy can be modified without
+// touching the original tree. Nodes defined outside this package are shared.
+func Copy(node Node) Node {
+	switch node := node.(type) {
+	case *Program:
+		return &Program{Statements: copyStatements(node.Statements)}
+
+	case *BlockStatements:
+		return copyBlock(node)
+
+	case *ExpressionStatement:
+		return &ExpressionStatement{Token: node.Token, Expression: copyExpression(node.Expression)}
+
+	case *LetStatement:
+		return copyLet(node)
+
+	case *UnpackStatement:
+		return &UnpackStatement{Token: node.Token, Names: copyIdentifiers(node.Names), Value: copyExpression(node.Value)}
+
+	case *ReturnStatement:
+		return &ReturnStatement{Token: node.Token, Value: copyExpression(node.Value)}
+
+	case *ImportStatement:
+		return &ImportStatement{Token: node.Token, Path: copyString(node.Path), Alias: copyIdentifier(node.Alias)}
+
+	case *ExportStatement:
+		return &ExportStatement{Token: node.Token, Statement: copyLet(node.Statement)}
+
+	case *UseStatement:
+		return &UseStatement{Token: node.Token, Kind: copyIdentifier(node.Kind), Value: copyString(node.Value)}
+
+	case *StructStatement:
+		return &StructStatement{Token: node.Token, Name: copyIdentifier(node.Name), Fields: copyIdentifiers(node.Fields)}
+
+	case *EnumStatement:
+		variants := make([]*EnumVariant, len(node.Variants))
+		for i, variant := range node.Variants {
+			variants[i] = &EnumVariant{Name: copyIdentifier(variant.Name), Fields: copyIdentifiers(variant.Fields)}
+		}
+		return &EnumStatement{Token: node.Token, Name: copyIdentifier(node.Name), Variants: variants}
+
+	case *ThrowStatement:
+		return &ThrowStatement{Token: node.Token, Value: copyExpression(node.Value)}
+
+	case *DeferStatement:
+		return &DeferStatement{Token: node.Token, Call: copyExpression(node.Call)}
+
+	case *ForStatement:
+		return &ForStatement{
+			Token:     node.Token,
+			Variables: copyIdentifiers(node.Variables),
+			Iterable:  copyExpression(node.Iterable),
+			Body:      copyBlock(node.Body),
+		}
+
+	case *ClassStatement:
+		methods := make([]*FunctionLiteral, len(node.Methods))
+		for i, method := range node.Methods {
+			methods[i] = copyFunction(method)
+		}
+		return &ClassStatement{Token: node.Token, Name: copyIdentifier(node.Name), Super: copyIdentifier(node.Super), Methods: methods}
+
+	case *FunctionStatement:
+		return &FunctionStatement{Token: node.Token, Function: copyFunction(node.Function)}
+
+	case *Identifier:
+		return copyIdentifier(node)
+
+	case *IntegerLiteral:
+		copied := *node
+		return &copied
+
+	case *StringLiteral:
+		return copyString(node)
+
+	case *Boolean:
+		copied := *node
+		return &copied
+
+	case *PrefixExpression:
+		return &PrefixExpression{Token: node.Token, Operator: node.Operator, Right: copyExpression(node.Right)}
+
+	case *InFixExpression:
+		return &InFixExpression{
+			Token:    node.Token,
+			Left:     copyExpression(node.Left),
+			Operator: node.Operator,
+			Right:    copyExpression(node.Right),
+		}
+
+	case *AssignExpression:
+		return &AssignExpression{
+			Token:    node.Token,
+			Name:     copyIdentifier(node.Name),
+			Operator: node.Operator,
+			Value:    copyExpression(node.Value),
+		}
+
+	case *MemberAssignExpression:
+		return &MemberAssignExpression{
+			Token:    node.Token,
+			Target:   copyMember(node.Target),
+			Operator: node.Operator,
+			Value:    copyExpression(node.Value),
+		}
+
+	case *MemberExpression:
+		return copyMember(node)
+
+	case *IndexExpression:
+		return &IndexExpression{Token: node.Token, Left: copyExpression(node.Left), Index: copyExpression(node.Index)}
+
+	case *RangeExpression:
+		return &RangeExpression{
+			Token: node.Token,
+			Start: copyExpression(node.Start),
+			End:   copyExpression(node.End),
+			Step:  copyExpression(node.Step),
+		}
+
+	case *ArrayLiteral:
+		return &ArrayLiteral{Token: node.Token, Elements: copyExpressions(node.Elements)}
+
+	case *TupleLiteral:
+		return &TupleLiteral{Token: node.Token, Elements: copyExpressions(node.Elements)}
+
+	case *HashLiteral:
+		pairs := make([]*HashLiteralPair, len(node.Pairs))
+		for i, pair := range node.Pairs {
+			pairs[i] = &HashLiteralPair{Key: copyExpression(pair.Key), Value: copyExpression(pair.Value)}
+		}
+		return &HashLiteral{Token: node.Token, Pairs: pairs}
+
+	case *FunctionLiteral:
+		return copyFunction(node)
+
+	case *MacroLiteral:
+		return &MacroLiteral{Token: node.Token, Parameters: copyIdentifiers(node.Parameters), Body: copyBlock(node.Body)}
+
+	case *CallExpression:
+		return &CallExpression{Token: node.Token, Function: copyExpression(node.Function), Arguments: copyExpressions(node.Arguments)}
+
+	case *KeywordArgument:
+		return &KeywordArgument{Token: node.Token, Name: copyIdentifier(node.Name), Value: copyExpression(node.Value)}
+
+	case *IfExpression:
+		return &IfExpression{
+			Token:       node.Token,
+			Confition:   copyExpression(node.Confition),
+			Consequence: copyBlock(node.Consequence),
+			Alternative: copyBlock(node.Alternative),
+		}
+
+	case *TryExpression:
+		return &TryExpression{
+			Token:     node.Token,
+			Block:     copyBlock(node.Block),
+			Parameter: copyIdentifier(node.Parameter),
+			Catch:     copyBlock(node.Catch),
+			Finally:   copyBlock(node.Finally),
+		}
+
+	case *MatchExpression:
+		arms := make([]*MatchArm, len(node.Arms))
+		for i, arm := range node.Arms {
+			arms[i] = &MatchArm{Pattern: copyExpression(arm.Pattern), Body: copyExpression(arm.Body)}
+		}
+		return &MatchExpression{Token: node.Token, Subject: copyExpression(node.Subject), Arms: arms}
+
+	case *YieldExpression:
+		return &YieldExpression{Token: node.Token, Value: copyExpression(node.Value)}
+
+	case *ArrayComprehension:
+		return &ArrayComprehension{Token: node.Token, Element: copyExpression(node.Element), Clauses: copyClauses(node.Clauses)}
+
+	case *HashComprehension:
+		return &HashComprehension{
+			Token:   node.Token,
+			Key:     copyExpression(node.Key),
+			Value:   copyExpression(node.Value),
+			Clauses: copyClauses(node.Clauses),
+		}
+	}
+
+	return node
+}
+
+func copyStatements(stmts []Statement) []Statement {
+	if stmts == nil {
+		return nil
+	}
+	copied := make([]Statement, len(stmts))
+	for i, stmt := range stmts {
+		if stmt != nil {
+			copied[i] = Copy(stmt).(Statement)
+		}
+	}
+	return copied
+}
+
+func copyExpression(exp Expression) Expression {
+	if exp == nil {
+		return nil
+	}
+	return Copy(exp).(Expression)
+}
+
+func copyExpressions(exps []Expression) []Expression {
+	if exps == nil {
+		return nil
+	}
+	copied := make([]Expression, len(exps))
+	for i, exp := range exps {
+		copied[i] = copyExpression(exp)
+	}
+	return copied
+}
+
+func copyIdentifier(ident *Identifier) *Identifier {
+	if ident == nil {
+		return nil
+	}
+	copied := *ident
+	return &copied
+}
+
+func copyIdentifiers(idents []*Identifier) []*Identifier {
+	if idents == nil {
+		return nil
+	}
+	copied := make([]*Identifier, len(idents))
+	for i, ident := range idents {
+		copied[i] = copyIdentifier(ident)
+	}
+	return copied
+}
+
+func copyString(str *StringLiteral) *StringLiteral {
+	if str == nil {
+		return nil
+	}
+	copied := *str
+	return &copied
+}
+
+func copyBlock(block *BlockStatements) *BlockStatements {
+	if block == nil {
+		return nil
+	}
+	return &BlockStatements{Token: block.Token, Statements: copyStatements(block.Statements)}
+}
+
+func copyLet(let *LetStatement) *LetStatement {
+	if let == nil {
+		return nil
+	}
+	return &LetStatement{
+		Token: let.Token,
+		Name:  copyIdentifier(let.Name),
+		Type:  copyIdentifier(let.Type),
+		Value: copyExpression(let.Value),
+	}
+}
+
+func copyMember(member *MemberExpression) *MemberExpression {
+	if member == nil {
+		return nil
+	}
+	return &MemberExpression{Token: member.Token, Object: copyExpression(member.Object), Property: copyIdentifier(member.Property)}
+}
+
+func copyFunction(fn *FunctionLiteral) *FunctionLiteral {
+	if fn == nil {
+		return nil
+	}
+	return &FunctionLiteral{
+		Token:          fn.Token,
+		Name:           copyIdentifier(fn.Name),
+		Parameters:     copyIdentifiers(fn.Parameters),
+		ParameterTypes: copyIdentifiers(fn.ParameterTypes),
+		ReturnType:     copyIdentifier(fn.ReturnType),
+		Requires:       copyExpressions(fn.Requires),
+		Ensures:        copyExpressions(fn.Ensures),
+		Body:           copyBlock(fn.Body),
+		IsGenerator:    fn.IsGenerator,
+	}
+}
+
+func copyClauses(clauses []*ComprehensionClause) []*ComprehensionClause {
+	if clauses == nil {
+		return nil
+	}
+	copied := make([]*ComprehensionClause, len(clauses))
+	for i, clause := range clauses {
+		copied[i] = &ComprehensionClause{
+			Token:      clause.Token,
+			Variables:  copyIdentifiers(clause.Variables),
+			Iterable:   copyExpression(clause.Iterable),
+			Conditions: copyExpressions(clause.Conditions),
+		}
+	}
+	return copied
+}
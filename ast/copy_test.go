@@ -0,0 +1,34 @@
+package ast_test
+
+import (
+	"testing"
+
+	"com.language/monkey/ast"
+)
+
+func TestCopyIsDeep(t *testing.T) {
+	program := parse(t, everyNode)
+	original := program.String()
+
+	copied := ast.Copy(program)
+	if copied.String() != original {
+		t.Fatalf("copy differs.\nwant %s\ngot  %s", original, copied.String())
+	}
+
+	ast.Modify(copied, func(node ast.Node) ast.Node {
+		switch node := node.(type) {
+		case *ast.Identifier:
+			node.Value += "_"
+		case *ast.IntegerLiteral:
+			node.Value++
+		}
+		return node
+	})
+
+	if program.String() != original {
+		t.Errorf("modifying the copy changed the original:\n%s", program.String())
+	}
+	if countIntegers(copied, 10) != 0 {
+		t.Errorf("copy was not modified")
+	}
+}
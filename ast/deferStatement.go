@@ -0,0 +1,20 @@
+package ast
+
+import "com.language/monkey/token"
+
+// defer <expression>;
+// the expression is evaluated when the enclosing function returns
+type DeferStatement struct {
+	Token token.Token
+	Call  Expression
+}
+
+func (ds *DeferStatement) statementNode() {}
+
+func (ds *DeferStatement) TokenLiteral() string {
+	return ds.Token.Literal
+}
+
+func (ds *DeferStatement) String() string {
+	return ds.TokenLiteral() + " " + ds.Call.String() + ";"
+}
@@ -0,0 +1,57 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"com.language/monkey/token"
+)
+
+// enum Shape { Circle(r), Rect(w, h), Empty }
+type EnumStatement struct {
+	Token    token.Token
+	Name     *Identifier
+	Variants []*EnumVariant
+}
+
+func (es *EnumStatement) statementNode() {}
+
+func (es *EnumStatement) TokenLiteral() string {
+	return es.Token.Literal
+}
+
+func (es *EnumStatement) String() string {
+	var out bytes.Buffer
+
+	variants := []string{}
+	for _, variant := range es.Variants {
+		variants = append(variants, variant.String())
+	}
+
+	out.WriteString(es.TokenLiteral() + " ")
+	out.WriteString(es.Name.String())
+	out.WriteString(" { ")
+	out.WriteString(strings.Join(variants, ", "))
+	out.WriteString(" }")
+
+	return out.String()
+}
+
+type EnumVariant struct {
+	Name *Identifier
+	// nil for variants without payload
+	Fields []*Identifier
+}
+
+func (ev *EnumVariant) String() string {
+	if ev.Fields == nil {
+		return ev.Name.String()
+	}
+
+	fields := []string{}
+	for _, field := range ev.Fields {
+		fields = append(fields, field.String())
+	}
+
+	return ev.Name.String() + "(" + strings.Join(fields, ", ") + ")"
+}
@@ -0,0 +1,11 @@
+package ast
+
+// ExpressionNode and StatementNode let packages outside ast define their
+// own nodes for parser extensions; embed one to satisfy Expression or Statement.
+type ExpressionNode struct{}
+
+func (ExpressionNode) expressionNode() {}
+
+type StatementNode struct{}
+
+func (StatementNode) statementNode() {}
@@ -0,0 +1,43 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"com.language/monkey/token"
+)
+
+// for x in xs { ... }
+// for k, v in h { ... }
+type ForStatement struct {
+	Token token.Token
+	// one name binds the element (the key for hashes), two bind key/index and value
+	Variables []*Identifier
+	Iterable  Expression
+	Body      *BlockStatements
+}
+
+func (fs *ForStatement) statementNode() {}
+
+func (fs *ForStatement) TokenLiteral() string {
+	return fs.Token.Literal
+}
+
+func (fs *ForStatement) String() string {
+	var out bytes.Buffer
+
+	vars := []string{}
+	for _, variable := range fs.Variables {
+		vars = append(vars, variable.String())
+	}
+
+	out.WriteString("for ")
+	out.WriteString(strings.Join(vars, ", "))
+	out.WriteString(" in ")
+	out.WriteString(fs.Iterable.String())
+	out.WriteString(" { ")
+	out.WriteString(fs.Body.String())
+	out.WriteString(" }")
+
+	return out.String()
+}
@@ -8,9 +8,19 @@ import (
 )
 
 type FunctionLiteral struct {
-	Token      token.Token
+	Token token.Token
+	// set for `fn name(...)`, nil for anonymous functions
+	Name       *Identifier
 	Parameters []*Identifier
-	Body       *BlockStatements
+	// parallel to Parameters, nil where a parameter is unannotated
+	ParameterTypes []*Identifier
+	ReturnType     *Identifier
+	// contract clauses; ensures may refer to the return value as result
+	Requires []Expression
+	Ensures  []Expression
+	Body     *BlockStatements
+	// the body yields, so calling the function returns a generator
+	IsGenerator bool
 }
 
 func (fl *FunctionLiteral) expressionNode() {
@@ -26,14 +36,30 @@ func (fl *FunctionLiteral) String() string {
 
 	params := []string{}
 
-	for _, param := range fl.Parameters {
-		params = append(params, param.String())
+	for i, param := range fl.Parameters {
+		if i < len(fl.ParameterTypes) && fl.ParameterTypes[i] != nil {
+			params = append(params, param.String()+": "+fl.ParameterTypes[i].String())
+		} else {
+			params = append(params, param.String())
+		}
 	}
 
 	out.WriteString(fl.Token.Literal)
+	if fl.Name != nil {
+		out.WriteString(" " + fl.Name.String())
+	}
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
 	out.WriteString(")")
+	if fl.ReturnType != nil {
+		out.WriteString(" -> " + fl.ReturnType.String() + " ")
+	}
+	for _, clause := range fl.Requires {
+		out.WriteString(" requires " + clause.String() + " ")
+	}
+	for _, clause := range fl.Ensures {
+		out.WriteString(" ensures " + clause.String() + " ")
+	}
 	//out.WriteString("{")
 	out.WriteString(fl.Body.String())
 	//out.WriteString("}")
@@ -0,0 +1,24 @@
+package ast
+
+import "com.language/monkey/token"
+
+/*
+fn name(params) { body }
+
+Declarations are hoisted: the name is bound before any statement of the
+enclosing block runs, so declared functions can call each other.
+*/
+type FunctionStatement struct {
+	Token    token.Token // fn
+	Function *FunctionLiteral
+}
+
+func (fs *FunctionStatement) statementNode() {}
+
+func (fs *FunctionStatement) TokenLiteral() string {
+	return fs.Token.Literal
+}
+
+func (fs *FunctionStatement) String() string {
+	return fs.Function.String()
+}
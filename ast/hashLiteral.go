@@ -9,7 +9,13 @@ import (
 
 type HashLiteral struct {
 	Token token.Token
-	Pairs map[Expression]Expression
+	// in source order
+	Pairs []*HashLiteralPair
+}
+
+type HashLiteralPair struct {
+	Key   Expression
+	Value Expression
 }
 
 func (hl *HashLiteral) expressionNode() {}
@@ -24,7 +30,7 @@ func (hl *HashLiteral) String() string {
 	pairs := []string{}
 
 	for _, itm := range hl.Pairs {
-		pairs = append(pairs, itm.String())
+		pairs = append(pairs, itm.Key.String()+":"+itm.Value.String())
 	}
 
 	out.WriteString("{")
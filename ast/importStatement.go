@@ -0,0 +1,49 @@
+package ast
+
+import (
+	"bytes"
+
+	"com.language/monkey/token"
+)
+
+// import "lib/strings.mk" as strs;
+type ImportStatement struct {
+	Token token.Token
+	Path  *StringLiteral
+	Alias *Identifier
+}
+
+func (is *ImportStatement) statementNode() {}
+
+func (is *ImportStatement) TokenLiteral() string {
+	return is.Token.Literal
+}
+
+func (is *ImportStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(is.TokenLiteral() + " ")
+	out.WriteString("\"" + is.Path.Value + "\"")
+	if is.Alias != nil {
+		out.WriteString(" as " + is.Alias.String())
+	}
+	out.WriteString(";")
+
+	return out.String()
+}
+
+// export let name = <expression>;
+type ExportStatement struct {
+	Token     token.Token
+	Statement *LetStatement
+}
+
+func (es *ExportStatement) statementNode() {}
+
+func (es *ExportStatement) TokenLiteral() string {
+	return es.Token.Literal
+}
+
+func (es *ExportStatement) String() string {
+	return es.TokenLiteral() + " " + es.Statement.String()
+}
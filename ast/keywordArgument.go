@@ -0,0 +1,20 @@
+package ast
+
+import "com.language/monkey/token"
+
+// KeywordArgument is an argument passed by parameter name: f(timeout: 30).
+type KeywordArgument struct {
+	Token token.Token // the name
+	Name  *Identifier
+	Value Expression
+}
+
+func (ka *KeywordArgument) expressionNode() {}
+
+func (ka *KeywordArgument) TokenLiteral() string {
+	return ka.Token.Literal
+}
+
+func (ka *KeywordArgument) String() string {
+	return ka.Name.String() + ": " + ka.Value.String()
+}
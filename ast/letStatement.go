@@ -8,10 +8,14 @@ import (
 
 /*
 let identifier = <expression>;
+let identifier: type = <expression>;
+const identifier = <expression>;
 */
 type LetStatement struct {
 	Token token.Token
 	Name  *Identifier
+	// optional annotation, checked when the statement runs and on every assignment
+	Type  *Identifier
 	Value Expression
 }
 
@@ -24,6 +28,9 @@ func (lt *LetStatement) String() string {
 
 	buf.WriteString(lt.TokenLiteral() + " ")
 	buf.WriteString(lt.Name.String())
+	if lt.Type != nil {
+		buf.WriteString(": " + lt.Type.String())
+	}
 	buf.WriteString(" = ")
 	if lt.Value != nil {
 		buf.WriteString(lt.Value.String())
@@ -36,3 +43,8 @@ func (lt *LetStatement) String() string {
 func (lt *LetStatement) TokenLiteral() string {
 	return lt.Token.Literal
 }
+
+// IsConst reports whether the binding was declared with const.
+func (lt *LetStatement) IsConst() bool {
+	return lt.Token.Type == token.CONST
+}
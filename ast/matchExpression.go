@@ -0,0 +1,54 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"com.language/monkey/token"
+)
+
+/*
+	match (shape) {
+		Shape.Circle(r) => r * r,
+		Shape.Rect(w, h) => { w * h },
+		_ => 0
+	}
+*/
+type MatchExpression struct {
+	Token   token.Token
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+func (me *MatchExpression) expressionNode() {}
+
+func (me *MatchExpression) TokenLiteral() string {
+	return me.Token.Literal
+}
+
+func (me *MatchExpression) String() string {
+	var out bytes.Buffer
+
+	arms := []string{}
+	for _, arm := range me.Arms {
+		arms = append(arms, arm.String())
+	}
+
+	out.WriteString("match")
+	out.WriteString(me.Subject.String())
+	out.WriteString(" { ")
+	out.WriteString(strings.Join(arms, ", "))
+	out.WriteString(" }")
+
+	return out.String()
+}
+
+type MatchArm struct {
+	// `_`, a value to compare with, or a variant call whose arguments are bindings
+	Pattern Expression
+	Body    Expression
+}
+
+func (ma *MatchArm) String() string {
+	return ma.Pattern.String() + " => " + ma.Body.String()
+}
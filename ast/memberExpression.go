@@ -0,0 +1,32 @@
+package ast
+
+import (
+	"bytes"
+
+	"com.language/monkey/token"
+)
+
+// strings.upper
+type MemberExpression struct {
+	Token    token.Token // .
+	Object   Expression
+	Property *Identifier
+}
+
+func (me *MemberExpression) expressionNode() {}
+
+func (me *MemberExpression) TokenLiteral() string {
+	return me.Token.Literal
+}
+
+func (me *MemberExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(me.Object.String())
+	out.WriteString(".")
+	out.WriteString(me.Property.String())
+	out.WriteString(")")
+
+	return out.String()
+}
@@ -0,0 +1,234 @@
+package ast
+
+import "fmt"
+
+type ModifierFunc func(Node) Node
+
+// Modify walks the tree depth-first, replacing every child by the result of
+// modifier before handing the node itself to modifier. It visits the same
+// children as Walk; optional children that are nil are left alone. Modify
+// panics if the modifier returns a node of the wrong kind for its slot.
+func Modify(node Node, modifier ModifierFunc) Node {
+	switch node := node.(type) {
+	case *Program:
+		modifyStatements(node.Statements, "Program.Statements", modifier)
+
+	case *BlockStatements:
+		modifyStatements(node.Statements, "BlockStatements.Statements", modifier)
+
+	case *ExpressionStatement:
+		node.Expression = modifyExpression(node.Expression, "ExpressionStatement.Expression", modifier)
+
+	case *LetStatement:
+		node.Name = modifySlot(node.Name, "LetStatement.Name", modifier)
+		node.Type = modifyIdentifier(node.Type, "LetStatement.Type", modifier)
+		node.Value = modifyExpression(node.Value, "LetStatement.Value", modifier)
+
+	case *UnpackStatement:
+		modifyIdentifiers(node.Names, "UnpackStatement.Names", modifier)
+		node.Value = modifyExpression(node.Value, "UnpackStatement.Value", modifier)
+
+	case *ReturnStatement:
+		node.Value = modifyExpression(node.Value, "ReturnStatement.Value", modifier)
+
+	case *ImportStatement:
+		node.Path = modifySlot(node.Path, "ImportStatement.Path", modifier)
+		node.Alias = modifyIdentifier(node.Alias, "ImportStatement.Alias", modifier)
+
+	case *ExportStatement:
+		node.Statement = modifySlot(node.Statement, "ExportStatement.Statement", modifier)
+
+	case *UseStatement:
+		node.Kind = modifySlot(node.Kind, "UseStatement.Kind", modifier)
+		node.Value = modifySlot(node.Value, "UseStatement.Value", modifier)
+
+	case *StructStatement:
+		node.Name = modifySlot(node.Name, "StructStatement.Name", modifier)
+		modifyIdentifiers(node.Fields, "StructStatement.Fields", modifier)
+
+	case *EnumStatement:
+		node.Name = modifySlot(node.Name, "EnumStatement.Name", modifier)
+		for _, variant := range node.Variants {
+			variant.Name = modifySlot(variant.Name, "EnumVariant.Name", modifier)
+			modifyIdentifiers(variant.Fields, "EnumVariant.Fields", modifier)
+		}
+
+	case *ThrowStatement:
+		node.Value = modifyExpression(node.Value, "ThrowStatement.Value", modifier)
+
+	case *DeferStatement:
+		node.Call = modifyExpression(node.Call, "DeferStatement.Call", modifier)
+
+	case *ForStatement:
+		modifyIdentifiers(node.Variables, "ForStatement.Variables", modifier)
+		node.Iterable = modifyExpression(node.Iterable, "ForStatement.Iterable", modifier)
+		node.Body = modifyBlock(node.Body, "ForStatement.Body", modifier)
+
+	case *ClassStatement:
+		node.Name = modifySlot(node.Name, "ClassStatement.Name", modifier)
+		node.Super = modifyIdentifier(node.Super, "ClassStatement.Super", modifier)
+		for i := range node.Methods {
+			node.Methods[i] = modifySlot(node.Methods[i], "ClassStatement.Methods", modifier)
+		}
+
+	case *FunctionStatement:
+		node.Function = modifySlot(node.Function, "FunctionStatement.Function", modifier)
+
+	case *PrefixExpression:
+		node.Right = modifyExpression(node.Right, "PrefixExpression.Right", modifier)
+
+	case *InFixExpression:
+		node.Left = modifyExpression(node.Left, "InFixExpression.Left", modifier)
+		node.Right = modifyExpression(node.Right, "InFixExpression.Right", modifier)
+
+	case *AssignExpression:
+		node.Name = modifySlot(node.Name, "AssignExpression.Name", modifier)
+		node.Value = modifyExpression(node.Value, "AssignExpression.Value", modifier)
+
+	case *MemberAssignExpression:
+		node.Target = modifySlot(node.Target, "MemberAssignExpression.Target", modifier)
+		node.Value = modifyExpression(node.Value, "MemberAssignExpression.Value", modifier)
+
+	case *MemberExpression:
+		node.Object = modifyExpression(node.Object, "MemberExpression.Object", modifier)
+		node.Property = modifySlot(node.Property, "MemberExpression.Property", modifier)
+
+	case *IndexExpression:
+		node.Left = modifyExpression(node.Left, "IndexExpression.Left", modifier)
+		node.Index = modifyExpression(node.Index, "IndexExpression.Index", modifier)
+
+	case *RangeExpression:
+		node.Start = modifyExpression(node.Start, "RangeExpression.Start", modifier)
+		node.End = modifyExpression(node.End, "RangeExpression.End", modifier)
+		node.Step = modifyExpression(node.Step, "RangeExpression.Step", modifier)
+
+	case *ArrayLiteral:
+		modifyExpressions(node.Elements, "ArrayLiteral.Elements", modifier)
+
+	case *TupleLiteral:
+		modifyExpressions(node.Elements, "TupleLiteral.Elements", modifier)
+
+	case *HashLiteral:
+		for _, pair := range node.Pairs {
+			pair.Key = modifyExpression(pair.Key, "HashLiteralPair.Key", modifier)
+			pair.Value = modifyExpression(pair.Value, "HashLiteralPair.Value", modifier)
+		}
+
+	case *FunctionLiteral:
+		node.Name = modifyIdentifier(node.Name, "FunctionLiteral.Name", modifier)
+		for i := range node.Parameters {
+			node.Parameters[i] = modifySlot(node.Parameters[i], "FunctionLiteral.Parameters", modifier)
+			if i < len(node.ParameterTypes) {
+				node.ParameterTypes[i] = modifyIdentifier(node.ParameterTypes[i], "FunctionLiteral.ParameterTypes", modifier)
+			}
+		}
+		node.ReturnType = modifyIdentifier(node.ReturnType, "FunctionLiteral.ReturnType", modifier)
+		modifyExpressions(node.Requires, "FunctionLiteral.Requires", modifier)
+		modifyExpressions(node.Ensures, "FunctionLiteral.Ensures", modifier)
+		node.Body = modifyBlock(node.Body, "FunctionLiteral.Body", modifier)
+
+	case *MacroLiteral:
+		modifyIdentifiers(node.Parameters, "MacroLiteral.Parameters", modifier)
+		node.Body = modifyBlock(node.Body, "MacroLiteral.Body", modifier)
+
+	case *CallExpression:
+		node.Function = modifyExpression(node.Function, "CallExpression.Function", modifier)
+		modifyExpressions(node.Arguments, "CallExpression.Arguments", modifier)
+
+	case *KeywordArgument:
+		node.Name = modifySlot(node.Name, "KeywordArgument.Name", modifier)
+		node.Value = modifyExpression(node.Value, "KeywordArgument.Value", modifier)
+
+	case *IfExpression:
+		node.Confition = modifyExpression(node.Confition, "IfExpression.Confition", modifier)
+		node.Consequence = modifyBlock(node.Consequence, "IfExpression.Consequence", modifier)
+		node.Alternative = modifyBlock(node.Alternative, "IfExpression.Alternative", modifier)
+
+	case *TryExpression:
+		node.Block = modifyBlock(node.Block, "TryExpression.Block", modifier)
+		node.Parameter = modifyIdentifier(node.Parameter, "TryExpression.Parameter", modifier)
+		node.Catch = modifyBlock(node.Catch, "TryExpression.Catch", modifier)
+		node.Finally = modifyBlock(node.Finally, "TryExpression.Finally", modifier)
+
+	case *MatchExpression:
+		node.Subject = modifyExpression(node.Subject, "MatchExpression.Subject", modifier)
+		for _, arm := range node.Arms {
+			arm.Pattern = modifyExpression(arm.Pattern, "MatchArm.Pattern", modifier)
+			arm.Body = modifyExpression(arm.Body, "MatchArm.Body", modifier)
+		}
+
+	case *YieldExpression:
+		node.Value = modifyExpression(node.Value, "YieldExpression.Value", modifier)
+
+	case *ArrayComprehension:
+		node.Element = modifyExpression(node.Element, "ArrayComprehension.Element", modifier)
+		modifyClauses(node.Clauses, modifier)
+
+	case *HashComprehension:
+		node.Key = modifyExpression(node.Key, "HashComprehension.Key", modifier)
+		node.Value = modifyExpression(node.Value, "HashComprehension.Value", modifier)
+		modifyClauses(node.Clauses, modifier)
+	}
+
+	return modifier(node)
+}
+
+// modifySlot replaces child by the result of Modify, panicking when the
+// modifier returns a node that cannot be stored in slot.
+func modifySlot[T Node](child T, slot string, modifier ModifierFunc) T {
+	modified := Modify(child, modifier)
+	replacement, ok := modified.(T)
+	if !ok {
+		panic(fmt.Sprintf("ast.Modify: %s cannot hold %T", slot, modified))
+	}
+	return replacement
+}
+
+func modifyStatements(stmts []Statement, slot string, modifier ModifierFunc) {
+	for i, stmt := range stmts {
+		if stmt != nil {
+			stmts[i] = modifySlot(stmt, slot, modifier)
+		}
+	}
+}
+
+func modifyExpression(exp Expression, slot string, modifier ModifierFunc) Expression {
+	if exp == nil {
+		return nil
+	}
+	return modifySlot(exp, slot, modifier)
+}
+
+func modifyExpressions(exps []Expression, slot string, modifier ModifierFunc) {
+	for i := range exps {
+		exps[i] = modifyExpression(exps[i], slot, modifier)
+	}
+}
+
+func modifyIdentifier(ident *Identifier, slot string, modifier ModifierFunc) *Identifier {
+	if ident == nil {
+		return nil
+	}
+	return modifySlot(ident, slot, modifier)
+}
+
+func modifyIdentifiers(idents []*Identifier, slot string, modifier ModifierFunc) {
+	for i := range idents {
+		idents[i] = modifySlot(idents[i], slot, modifier)
+	}
+}
+
+func modifyBlock(block *BlockStatements, slot string, modifier ModifierFunc) *BlockStatements {
+	if block == nil {
+		return nil
+	}
+	return modifySlot(block, slot, modifier)
+}
+
+func modifyClauses(clauses []*ComprehensionClause, modifier ModifierFunc) {
+	for _, clause := range clauses {
+		modifyIdentifiers(clause.Variables, "ComprehensionClause.Variables", modifier)
+		clause.Iterable = modifyExpression(clause.Iterable, "ComprehensionClause.Iterable", modifier)
+		modifyExpressions(clause.Conditions, "ComprehensionClause.Conditions", modifier)
+	}
+}
@@ -0,0 +1,112 @@
+package ast_test
+
+import (
+	"testing"
+
+	"com.language/monkey/ast"
+)
+
+func countIntegers(node ast.Node, value int64) int {
+	count := 0
+	ast.Inspect(node, func(node ast.Node) bool {
+		if integer, ok := node.(*ast.IntegerLiteral); ok && integer.Value == value {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+func TestModifyReplacesInEveryNodeType(t *testing.T) {
+	program := parse(t, `
+export const one: int = 1
+let (a, b) = (1, [1][1])
+class C { init() { self.n = 1; self.n += 1 } }
+fn g(x) ensures result > 1 { 1 }
+fn f(x: int) requires x > 1 {
+	for i in 1..1 step 1 { yield {1: 1} }
+	defer g(1, k: 1)
+	throw 1
+}
+let t = try { 1 } catch (e) { -1 } finally { 1 }
+match (1) { 1 => 1 }
+if (1) { x = 1 } else { 1 }
+let m = macro(a) { 1 }
+[1 for x in 1 if 1]; {1: 1 for k, v in 1 if 1}
+`)
+
+	before := countIntegers(program, 1)
+	ast.Modify(program, func(node ast.Node) ast.Node {
+		if integer, ok := node.(*ast.IntegerLiteral); ok && integer.Value == 1 {
+			integer.Value = 2
+		}
+		return node
+	})
+
+	if left := countIntegers(program, 1); left != 0 {
+		t.Errorf("%d integers were not modified", left)
+	}
+	if after := countIntegers(program, 2); after != before {
+		t.Errorf("expect %d modified integers, got %d", before, after)
+	}
+}
+
+// folding constants needs the children to be replaced before their parent
+func TestModifyIsBottomUp(t *testing.T) {
+	program := parse(t, `let x = 1 + 2 * (3 - 1); f(k: -(4 - 2))`)
+
+	fold := func(node ast.Node) ast.Node {
+		infix, ok := node.(*ast.InFixExpression)
+		if !ok {
+			return node
+		}
+		left, okLeft := infix.Left.(*ast.IntegerLiteral)
+		right, okRight := infix.Right.(*ast.IntegerLiteral)
+		if !okLeft || !okRight {
+			return node
+		}
+
+		switch infix.Operator {
+		case "+":
+			left.Value += right.Value
+		case "-":
+			left.Value -= right.Value
+		case "*":
+			left.Value *= right.Value
+		default:
+			return node
+		}
+		return left
+	}
+
+	ast.Modify(program, fold)
+
+	let := program.Statements[0].(*ast.LetStatement)
+	if folded, ok := let.Value.(*ast.IntegerLiteral); !ok || folded.Value != 5 {
+		t.Errorf("expect let value folded to 5, got %s", let.Value)
+	}
+	call := program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	negated := call.Arguments[0].(*ast.KeywordArgument).Value.(*ast.PrefixExpression)
+	if folded, ok := negated.Right.(*ast.IntegerLiteral); !ok || folded.Value != 2 {
+		t.Errorf("expect keyword argument folded to -2, got %s", negated)
+	}
+}
+
+func TestModifyRejectsWrongKindOfNode(t *testing.T) {
+	program := parse(t, `let x = y`)
+
+	defer func() {
+		r := recover()
+		expected := "ast.Modify: LetStatement.Name cannot hold *ast.IntegerLiteral"
+		if r != expected {
+			t.Errorf("expect panic %q, got %v", expected, r)
+		}
+	}()
+
+	ast.Modify(program, func(node ast.Node) ast.Node {
+		if _, ok := node.(*ast.Identifier); ok {
+			return &ast.IntegerLiteral{Value: 1}
+		}
+		return node
+	})
+}
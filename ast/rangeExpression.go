@@ -0,0 +1,38 @@
+package ast
+
+import (
+	"bytes"
+
+	"com.language/monkey/token"
+)
+
+// 0..10, 0..n step 2
+type RangeExpression struct {
+	Token token.Token // ..
+	Start Expression
+	End   Expression
+	// nil means 1
+	Step Expression
+}
+
+func (re *RangeExpression) expressionNode() {}
+
+func (re *RangeExpression) TokenLiteral() string {
+	return re.Token.Literal
+}
+
+func (re *RangeExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(re.Start.String())
+	out.WriteString("..")
+	out.WriteString(re.End.String())
+	if re.Step != nil {
+		out.WriteString(" step ")
+		out.WriteString(re.Step.String())
+	}
+	out.WriteString(")")
+
+	return out.String()
+}
@@ -0,0 +1,43 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"com.language/monkey/token"
+)
+
+/*
+(a, b)
+(a,)
+()
+*/
+type TupleLiteral struct {
+	Token    token.Token
+	Elements []Expression
+}
+
+func (tl *TupleLiteral) expressionNode() {}
+
+func (tl *TupleLiteral) TokenLiteral() string {
+	return tl.Token.Literal
+}
+
+func (tl *TupleLiteral) String() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+
+	for _, ele := range tl.Elements {
+		elements = append(elements, ele.String())
+	}
+
+	out.WriteString("(")
+	out.WriteString(strings.Join(elements, ", "))
+	if len(elements) == 1 {
+		out.WriteString(",")
+	}
+	out.WriteString(")")
+
+	return out.String()
+}
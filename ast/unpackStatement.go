@@ -0,0 +1,47 @@
+package ast
+
+import (
+	"bytes"
+
+	"com.language/monkey/token"
+)
+
+/*
+let (x, y) = <expression>;
+const (x, y) = <expression>;
+*/
+type UnpackStatement struct {
+	Token token.Token
+	Names []*Identifier
+	Value Expression
+}
+
+func (us *UnpackStatement) statementNode() {}
+
+func (us *UnpackStatement) TokenLiteral() string {
+	return us.Token.Literal
+}
+
+func (us *UnpackStatement) String() string {
+	var buf = bytes.Buffer{}
+
+	buf.WriteString(us.TokenLiteral() + " (")
+	for i, name := range us.Names {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(name.String())
+	}
+	buf.WriteString(") = ")
+	if us.Value != nil {
+		buf.WriteString(us.Value.String())
+	}
+	buf.WriteString(";")
+
+	return buf.String()
+}
+
+// IsConst reports whether the bindings were declared with const.
+func (us *UnpackStatement) IsConst() bool {
+	return us.Token.Type == token.CONST
+}
@@ -0,0 +1,24 @@
+package ast
+
+import "com.language/monkey/token"
+
+/*
+use edition "2026";
+use feature "loops";
+*/
+type UseStatement struct {
+	Token token.Token
+	// edition or feature
+	Kind  *Identifier
+	Value *StringLiteral
+}
+
+func (us *UseStatement) statementNode() {}
+
+func (us *UseStatement) TokenLiteral() string {
+	return us.Token.Literal
+}
+
+func (us *UseStatement) String() string {
+	return us.TokenLiteral() + " " + us.Kind.String() + " \"" + us.Value.String() + "\";"
+}
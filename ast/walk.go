@@ -0,0 +1,242 @@
+package ast
+
+// A Visitor's Visit method is called for every node Walk encounters. If it
+// returns a non-nil visitor w, Walk visits each child of the node with w and
+// then calls w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses the tree rooted at node depth-first, children in source
+// order. Identifiers and literals are leaves, and so are nodes defined
+// outside this package.
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch node := node.(type) {
+	case *Program:
+		walkStatements(v, node.Statements)
+
+	case *BlockStatements:
+		walkStatements(v, node.Statements)
+
+	case *ExpressionStatement:
+		walkExpression(v, node.Expression)
+
+	case *LetStatement:
+		Walk(v, node.Name)
+		walkIdentifier(v, node.Type)
+		walkExpression(v, node.Value)
+
+	case *UnpackStatement:
+		walkIdentifiers(v, node.Names)
+		walkExpression(v, node.Value)
+
+	case *ReturnStatement:
+		walkExpression(v, node.Value)
+
+	case *ImportStatement:
+		Walk(v, node.Path)
+		walkIdentifier(v, node.Alias)
+
+	case *ExportStatement:
+		Walk(v, node.Statement)
+
+	case *UseStatement:
+		Walk(v, node.Kind)
+		Walk(v, node.Value)
+
+	case *StructStatement:
+		Walk(v, node.Name)
+		walkIdentifiers(v, node.Fields)
+
+	case *EnumStatement:
+		Walk(v, node.Name)
+		for _, variant := range node.Variants {
+			Walk(v, variant.Name)
+			walkIdentifiers(v, variant.Fields)
+		}
+
+	case *ThrowStatement:
+		walkExpression(v, node.Value)
+
+	case *DeferStatement:
+		walkExpression(v, node.Call)
+
+	case *ForStatement:
+		walkIdentifiers(v, node.Variables)
+		walkExpression(v, node.Iterable)
+		Walk(v, node.Body)
+
+	case *ClassStatement:
+		Walk(v, node.Name)
+		walkIdentifier(v, node.Super)
+		for _, method := range node.Methods {
+			Walk(v, method)
+		}
+
+	case *FunctionStatement:
+		Walk(v, node.Function)
+
+	case *PrefixExpression:
+		walkExpression(v, node.Right)
+
+	case *InFixExpression:
+		walkExpression(v, node.Left)
+		walkExpression(v, node.Right)
+
+	case *AssignExpression:
+		Walk(v, node.Name)
+		walkExpression(v, node.Value)
+
+	case *MemberAssignExpression:
+		Walk(v, node.Target)
+		walkExpression(v, node.Value)
+
+	case *MemberExpression:
+		walkExpression(v, node.Object)
+		Walk(v, node.Property)
+
+	case *IndexExpression:
+		walkExpression(v, node.Left)
+		walkExpression(v, node.Index)
+
+	case *RangeExpression:
+		walkExpression(v, node.Start)
+		walkExpression(v, node.End)
+		walkExpression(v, node.Step)
+
+	case *ArrayLiteral:
+		walkExpressions(v, node.Elements)
+
+	case *TupleLiteral:
+		walkExpressions(v, node.Elements)
+
+	case *HashLiteral:
+		for _, pair := range node.Pairs {
+			walkExpression(v, pair.Key)
+			walkExpression(v, pair.Value)
+		}
+
+	case *FunctionLiteral:
+		walkIdentifier(v, node.Name)
+		for i, param := range node.Parameters {
+			Walk(v, param)
+			if i < len(node.ParameterTypes) {
+				walkIdentifier(v, node.ParameterTypes[i])
+			}
+		}
+		walkIdentifier(v, node.ReturnType)
+		walkExpressions(v, node.Requires)
+		walkExpressions(v, node.Ensures)
+		Walk(v, node.Body)
+
+	case *MacroLiteral:
+		walkIdentifiers(v, node.Parameters)
+		Walk(v, node.Body)
+
+	case *CallExpression:
+		walkExpression(v, node.Function)
+		walkExpressions(v, node.Arguments)
+
+	case *KeywordArgument:
+		Walk(v, node.Name)
+		walkExpression(v, node.Value)
+
+	case *IfExpression:
+		walkExpression(v, node.Confition)
+		Walk(v, node.Consequence)
+		if node.Alternative != nil {
+			Walk(v, node.Alternative)
+		}
+
+	case *TryExpression:
+		Walk(v, node.Block)
+		walkIdentifier(v, node.Parameter)
+		if node.Catch != nil {
+			Walk(v, node.Catch)
+		}
+		if node.Finally != nil {
+			Walk(v, node.Finally)
+		}
+
+	case *MatchExpression:
+		walkExpression(v, node.Subject)
+		for _, arm := range node.Arms {
+			walkExpression(v, arm.Pattern)
+			walkExpression(v, arm.Body)
+		}
+
+	case *YieldExpression:
+		walkExpression(v, node.Value)
+
+	case *ArrayComprehension:
+		walkExpression(v, node.Element)
+		walkClauses(v, node.Clauses)
+
+	case *HashComprehension:
+		walkExpression(v, node.Key)
+		walkExpression(v, node.Value)
+		walkClauses(v, node.Clauses)
+	}
+
+	v.Visit(nil)
+}
+
+func walkStatements(v Visitor, stmts []Statement) {
+	for _, stmt := range stmts {
+		if stmt != nil {
+			Walk(v, stmt)
+		}
+	}
+}
+
+func walkExpression(v Visitor, exp Expression) {
+	if exp != nil {
+		Walk(v, exp)
+	}
+}
+
+func walkExpressions(v Visitor, exps []Expression) {
+	for _, exp := range exps {
+		walkExpression(v, exp)
+	}
+}
+
+// walkIdentifier skips the optional identifiers, such as types, that are nil
+func walkIdentifier(v Visitor, ident *Identifier) {
+	if ident != nil {
+		Walk(v, ident)
+	}
+}
+
+func walkIdentifiers(v Visitor, idents []*Identifier) {
+	for _, ident := range idents {
+		Walk(v, ident)
+	}
+}
+
+func walkClauses(v Visitor, clauses []*ComprehensionClause) {
+	for _, clause := range clauses {
+		walkIdentifiers(v, clause.Variables)
+		walkExpression(v, clause.Iterable)
+		walkExpressions(v, clause.Conditions)
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the tree like Walk, calling f for every node and with
+// nil after a node's children. f returning false skips the node's children.
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
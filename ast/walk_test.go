@@ -0,0 +1,126 @@
+package ast_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/lexer"
+	"com.language/monkey/parser"
+)
+
+// uses every kind of node the parser produces
+const everyNode = `
+use edition "2026"
+import "lib.mk" as lib
+export const limit: int = 10
+let (q, r) = (7 / 2, 7 - 6)
+struct Point { x, y }
+enum Shape { Empty, Circle(radius) }
+class Counter < Base {
+	init(n) { self.n = n; self.n += 1 }
+}
+fn id(x) ensures result == x { x }
+fn gen(xs: array) -> generator requires len(xs) > 0 {
+	for i, x in xs { yield x }
+	defer puts("done")
+	return
+}
+let m = macro(a) { quote(unquote(a)) }
+let h = {"k": [1, 2][0], "r": 0..10 step 2}
+let t = try { throw "x" } catch (e) { e } finally { !false }
+match (-q) { 1 => "one", _ => "many" }
+if (q > r) { q = r } else { r }
+let squares = [x * x for x in xs if x > 1]
+let inverse = {v: k for k, v in h}
+request(lib.url, timeout: 30)
+`
+
+func parse(t *testing.T, input string) *ast.Program {
+	p := parser.New(lexer.New(input))
+	program := p.ParserProgram()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+	return program
+}
+
+func TestInspectVisitsEveryNodeType(t *testing.T) {
+	program := parse(t, everyNode)
+
+	seen := map[string]bool{}
+	ast.Inspect(program, func(node ast.Node) bool {
+		if node != nil {
+			seen[reflect.TypeOf(node).Elem().Name()] = true
+		}
+		return true
+	})
+
+	expected := []string{
+		"Program", "UseStatement", "ImportStatement", "ExportStatement", "LetStatement",
+		"UnpackStatement", "StructStatement", "EnumStatement", "ClassStatement",
+		"FunctionStatement", "ForStatement", "DeferStatement", "ReturnStatement",
+		"ThrowStatement", "ExpressionStatement", "BlockStatements", "Identifier",
+		"IntegerLiteral", "StringLiteral", "Boolean", "PrefixExpression",
+		"InFixExpression", "AssignExpression", "MemberAssignExpression",
+		"MemberExpression", "IndexExpression", "RangeExpression", "ArrayLiteral",
+		"HashLiteral", "TupleLiteral", "FunctionLiteral", "MacroLiteral",
+		"CallExpression", "KeywordArgument", "IfExpression", "TryExpression",
+		"MatchExpression", "YieldExpression", "ArrayComprehension", "HashComprehension",
+	}
+	for _, name := range expected {
+		if !seen[name] {
+			t.Errorf("%s was not visited", name)
+		}
+	}
+}
+
+func TestInspectOrderAndPruning(t *testing.T) {
+	program := parse(t, `let f = fn(a: int) { a + b[1] }; f(c)`)
+
+	visited := []string{}
+	ast.Inspect(program, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.Identifier:
+			visited = append(visited, node.Value)
+		case *ast.IndexExpression:
+			// skip b[1]
+			return false
+		}
+		return true
+	})
+
+	if fmt.Sprint(visited) != "[f a int a f c]" {
+		t.Errorf("wrong visiting order: %v", visited)
+	}
+}
+
+type depthVisitor struct {
+	depth    *int
+	maxDepth *int
+}
+
+func (v depthVisitor) Visit(node ast.Node) ast.Visitor {
+	if node == nil {
+		*v.depth--
+		return nil
+	}
+	*v.depth++
+	if *v.depth > *v.maxDepth {
+		*v.maxDepth = *v.depth
+	}
+	return v
+}
+
+func TestWalkBalancesVisits(t *testing.T) {
+	program := parse(t, `1 + (2 * 3)`)
+
+	depth, maxDepth := 0, 0
+	ast.Walk(depthVisitor{&depth, &maxDepth}, program)
+
+	// Program > ExpressionStatement > + > * > 2
+	if depth != 0 || maxDepth != 5 {
+		t.Errorf("expect balanced walk of depth 5, got depth %d, max %d", depth, maxDepth)
+	}
+}
@@ -0,0 +1,19 @@
+package ast
+
+import "com.language/monkey/token"
+
+// yield <expression>
+type YieldExpression struct {
+	Token token.Token
+	Value Expression
+}
+
+func (ye *YieldExpression) expressionNode() {}
+
+func (ye *YieldExpression) TokenLiteral() string {
+	return ye.Token.Literal
+}
+
+func (ye *YieldExpression) String() string {
+	return "(" + ye.TokenLiteral() + " " + ye.Value.String() + ")"
+}
@@ -18,6 +18,14 @@ var Builtins = map[string]*object.Builtin{
 				return &object.Integer{Value: int64(len(arg.Value))}
 			case *object.Array:
 				return &object.Integer{Value: int64(len(arg.Elements))}
+			case *object.Tuple:
+				return &object.Integer{Value: int64(len(arg.Elements))}
+			case *object.Range:
+				n, ok := arg.Len()
+				if !ok {
+					return NewError("range %s has more elements than an integer can count", arg.Inspect())
+				}
+				return &object.Integer{Value: n}
 			default:
 				return NewError("argument to `len` not support. got %s", args[0].Type())
 			}
@@ -95,12 +103,69 @@ var Builtins = map[string]*object.Builtin{
 			return &object.Array{Elements: newElems}
 		},
 	},
-	"puts": &object.Builtin{
+	"next": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return NewError("wrong number of arguments. got %d, want 1", len(args))
+			}
+
+			gen, ok := args[0].(*object.Generator)
+			if !ok {
+				return NewError("argument to `next` must be GENERATOR, got %s", args[0].Type())
+			}
+
+			// exhausted generators keep returning null
+			value, _ := gen.Next()
+			return value
+		},
+	},
+	"take": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return NewError("wrong number of arguments. got %d, want 2", len(args))
+			}
+
+			n, ok := args[1].(*object.Integer)
+			if !ok {
+				return NewError("second argument to `take` must be INTEGER, got %s", args[1].Type())
+			}
+
+			elements := []object.Object{}
+			if n.Value <= 0 {
+				return &object.Array{Elements: elements}
+			}
+
+			// any non-nil result stops the iteration early
+			err := iterate(args[0], func(key, value object.Object) object.Object {
+				elements = append(elements, value)
+				if int64(len(elements)) >= n.Value {
+					return NULL
+				}
+				return nil
+			})
+			if IsError(err) {
+				return err
+			}
+
+			return &object.Array{Elements: elements}
+		},
+	},
+	"range": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			return newRange(args...)
+		},
+	},
+}
+
+// puts is registered in init because Inspect can call back into Eval
+// for __str__, which would make Builtins refer to itself.
+func init() {
+	Builtins["puts"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			for _, arg := range args {
-				fmt.Println(arg.Inspect())
+				fmt.Println(Inspect(arg))
 			}
 			return NULL
 		},
-	},
+	}
 }
@@ -0,0 +1,116 @@
+package evaluator
+
+import (
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+)
+
+func evalClassStatement(node *ast.ClassStatement, env *object.Environement) object.Object {
+	if decl, ok := env.Constant(node.Name.Value); ok {
+		return withPosition(NewError("%s", parser.ConstantError("redeclare", decl)), node.Name.Token)
+	}
+
+	class := &object.Class{Name: node.Name.Value, Methods: map[string]*object.Function{}}
+
+	if node.Super != nil {
+		super := evalIdentifier(node.Super, env)
+		if IsError(super) {
+			return withPosition(super, node.Super.Token)
+		}
+		superClass, ok := super.(*object.Class)
+		if !ok {
+			return withPosition(NewError("superclass of %s is not a class: %s", class.Name, super.Type()), node.Super.Token)
+		}
+		class.Super = superClass
+	}
+
+	for _, method := range node.Methods {
+		class.Methods[method.Name.Value] = newFunction(method, env)
+	}
+
+	env.Set(class.Name, class)
+	return nil
+}
+
+// newInstance builds an instance of class and runs its init, if any, with args.
+func newInstance(class *object.Class, args []object.Object) object.Object {
+	instance := object.NewInstance(class)
+
+	init, owner := class.Lookup("init")
+	if init == nil {
+		if len(args) != 0 {
+			return NewError("wrong number of arguments to %s. got %d, want 0", class.Name, len(args))
+		}
+		return instance
+	}
+
+	result := callMethod(&object.BoundMethod{Receiver: instance, Method: init, Owner: owner}, args)
+	if IsError(result) {
+		return result
+	}
+	return instance
+}
+
+// callMethod runs a method with self, and super when the defining class has a parent, bound.
+func callMethod(method *object.BoundMethod, args []object.Object) object.Object {
+	return callFunction(method.Method, args, func(env *object.Environement) {
+		env.Set("self", method.Receiver)
+		if method.Owner.Super != nil {
+			env.Set("super", &object.Super{Receiver: method.Receiver, Class: method.Owner.Super})
+		}
+	})
+}
+
+// fields shadow methods of the same name
+func evalInstanceMember(instance *object.Instance, name string) object.Object {
+	if val, ok := instance.Get(name); ok {
+		return val
+	}
+
+	if method, owner := instance.Class.Lookup(name); method != nil {
+		return &object.BoundMethod{Receiver: instance, Method: method, Owner: owner}
+	}
+
+	return NewError("%s has no field or method %s", instance.Class.Name, name)
+}
+
+func evalSuperMember(super *object.Super, name string) object.Object {
+	method, owner := super.Class.Lookup(name)
+	if method == nil {
+		return NewError("%s has no method %s", super.Class.Name, name)
+	}
+
+	return &object.BoundMethod{Receiver: super.Receiver, Method: method, Owner: owner}
+}
+
+func evalMemberAssignExpression(node *ast.MemberAssignExpression, env *object.Environement) object.Object {
+	obj := Eval(node.Target.Object, env)
+	if IsError(obj) {
+		return obj
+	}
+
+	name := node.Target.Property.Value
+	instance, ok := obj.(*object.Instance)
+	if !ok {
+		return NewError("cannot assign to field %s of %s", name, obj.Type())
+	}
+
+	val := Eval(node.Value, env)
+	if IsError(val) {
+		return val
+	}
+
+	if node.Operator != "" {
+		current, ok := instance.Get(name)
+		if !ok {
+			return NewError("%s has no field %s", instance.Class.Name, name)
+		}
+		val = evalInfixExpression(node.Operator, current, val)
+		if IsError(val) {
+			return val
+		}
+	}
+
+	return instance.Set(name, val)
+}
@@ -0,0 +1,124 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+const classes = `
+class Counter {
+	init(start) { self.n = start }
+	inc() { self.n += 1; self }
+	get() { self.n }
+}
+
+class Animal {
+	init(name) { self.name = name }
+	speak() { self.name + " makes a sound" }
+	describe() { "I am " + self.name }
+}
+
+class Dog < Animal {
+	init(name, breed) {
+		super.init(name)
+		self.breed = breed
+	}
+	speak() { self.name + " barks" }
+	both() { [super.speak(), self.speak()] }
+}
+
+class Puppy < Dog {
+	speak() { "small " + super.speak() }
+}
+
+class Vec {
+	init(x, y) { self.x = x; self.y = y }
+	__add__(other) { Vec(self.x + other.x, self.y + other.y) }
+	__eq__(other) { if (self.x == other.x) { self.y == other.y } else { false } }
+	__index__(i) { if (i == 0) { self.x } else { self.y } }
+}
+`
+
+func TestClasses(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let c = Counter(5); c.inc(); c.inc().get()`, "7"},
+		{`let c = Counter(0); c.n = 10; c.n -= 3; c.n`, "7"},
+		{`Counter(1)`, "Counter{n: 1}"},
+		{`Dog("Rex", "lab")`, "Dog{name: Rex, breed: lab}"},
+		{`Counter`, "<class Counter>"},
+		{`Counter(1).inc`, "<method Counter.inc/0>"},
+		{`Dog("Rex", "lab").speak()`, "Rex barks"},
+		{`Dog("Rex", "lab").describe()`, "I am Rex"},
+		{`Dog("Rex", "lab").both()`, "[Rex makes a sound, Rex barks]"},
+		{`Puppy("Bit", "pug").speak()`, "small Bit barks"},
+		{`Puppy("Bit", "pug").both()`, "[Bit makes a sound, small Bit barks]"},
+		{`let inc = Counter(1).inc; inc(); inc().n`, "3"},
+		{`class Empty { }; Empty()`, "Empty{}"},
+		{`class P { init() { self.x = 1 } x() { 2 } }; P().x`, "1"},
+		{`let v = Vec(1, 2) + Vec(3, 4); [v.x, v.y, v[0], v[1]]`, "[4, 6, 4, 6]"},
+		{`Vec(1, 2) == Vec(1, 2)`, "true"},
+		{`Vec(1, 2) != Vec(1, 3)`, "true"},
+		{`let c = Counter(1); c == c`, "true"},
+		{`let f = fn(a: Animal) -> string { a.speak() }; f(Dog("Rex", "lab"))`, "Rex barks"},
+		{`let x = 1; x += 2; x *= 4; x /= 3; x -= 1; x`, "3"},
+		{`let s = "a"; s += "b"; s`, "ab"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(classes + itm.input)
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestClassErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Counter(1).missing`, "Counter has no field or method missing"},
+		{`Counter()`, "wrong number of arguments to init. got 0, want 1"},
+		{`class E { }; E(1)`, "wrong number of arguments to E. got 1, want 0"},
+		{`Counter(1).inc(5)`, "wrong number of arguments to inc. got 1, want 0"},
+		{`Animal("a").speak(); class Bad < Animal { speak() { super.fly() } }; Bad("b").speak()`, "Animal has no method fly"},
+		{`Counter(0).describe()`, "Counter has no field or method describe"},
+		{`let x = 1; class C < x { }`, "superclass of C is not a class: INTEGER"},
+		{`class C < Nope { }`, "identifier not fond: Nope"},
+		{`class C { m() { super.m() } }; C().m()`, "identifier not fond: super"},
+		{`let h = {}; h.x = 1`, "cannot assign to field x of HASH"},
+		{`let c = Counter(1); c.total += 1`, "Counter has no field total"},
+		{`let f = fn(a: Dog) { a }; f(Animal("a"))`, "type mismatch for parameter a of f: expected Dog, got INSTANCE"},
+		{`Counter(1)[0]`, "index operator not supported: Counter"},
+		{`let y = "a"; y -= 1`, "type mismatch: STRING - INTEGER"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(classes + itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != itm.expected {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expected, errObj.Message)
+		}
+	}
+}
+
+func TestClassInspect(t *testing.T) {
+	input := classes + `
+class Named {
+	init(name) { self.name = name }
+	__str__() { "<" + self.name + ">" }
+}
+[Named("a"), Counter(Named("b"))]
+`
+	if got := Inspect(testEval(input)); got != "[<a>, Counter{n: <b>}]" {
+		t.Errorf("expect [<a>, Counter{n: <b>}], got %s", got)
+	}
+}
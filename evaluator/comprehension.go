@@ -0,0 +1,84 @@
+package evaluator
+
+import (
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+)
+
+func evalArrayComprehension(node *ast.ArrayComprehension, env *object.Environement) object.Object {
+	elements := []object.Object{}
+
+	result := evalComprehensionClauses(node.Clauses, env, func(scope *object.Environement) object.Object {
+		element := Eval(node.Element, scope)
+		if IsError(element) {
+			return element
+		}
+		elements = append(elements, element)
+		return nil
+	})
+	if IsError(result) {
+		return result
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+func evalHashComprehension(node *ast.HashComprehension, env *object.Environement) object.Object {
+	hash := object.NewHash()
+
+	result := evalComprehensionClauses(node.Clauses, env, func(scope *object.Environement) object.Object {
+		key := Eval(node.Key, scope)
+		if IsError(key) {
+			return key
+		}
+
+		hashKey, ok := object.Hashable(key)
+		if !ok {
+			return NewError("unusable as hash key: %s", key.Type())
+		}
+
+		val := Eval(node.Value, scope)
+		if IsError(val) {
+			return val
+		}
+
+		hash.Set(hashKey.HashKey(), object.HashPair{Key: key, Value: val})
+		return nil
+	})
+	if IsError(result) {
+		return result
+	}
+
+	return hash
+}
+
+// runs emit once for every combination of the clauses that passes all conditions,
+// the first clause being the outermost loop
+func evalComprehensionClauses(clauses []*ast.ComprehensionClause, env *object.Environement, emit func(*object.Environement) object.Object) object.Object {
+	if len(clauses) == 0 {
+		return emit(env)
+	}
+
+	clause := clauses[0]
+	iterable := Eval(clause.Iterable, env)
+	if IsError(iterable) {
+		return iterable
+	}
+
+	return iterate(iterable, func(key, value object.Object) object.Object {
+		scope := object.NewEnclosedEnvironment(env)
+		bindLoopVariables(clause.Variables, iterable, key, value, scope)
+
+		for _, cond := range clause.Conditions {
+			ok := Eval(cond, scope)
+			if IsError(ok) {
+				return ok
+			}
+			if !isTruthy(ok) {
+				return nil
+			}
+		}
+
+		return evalComprehensionClauses(clauses[1:], scope, emit)
+	})
+}
@@ -0,0 +1,56 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func TestComprehensions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`[x * 2 for x in [1, 2, 3]]`, "[2, 4, 6]"},
+		{`[x * 2 for x in [1, 2, 3] if x > 1]`, "[4, 6]"},
+		{`[x for x in 0..10 step 2 if x > 2 if x < 8]`, "[4, 6]"},
+		{`[[x, y] for x in 1..3 for y in 0..x]`, "[[1, 0], [2, 0], [2, 1]]"},
+		{`[i for i, c in "abc"]`, "[0, 1, 2]"},
+		{`[k for k in {"a": 1, "b": 2}]`, "[a, b]"},
+		{`[x for x in []]`, "[]"},
+		{`let x = 100; [x for x in [1]]; x`, "100"},
+		{`{k: v * 10 for k, v in {"a": 1, "b": 2}}`, "{a:10, b:20}"},
+		{`{x: x * x for x in 1..4 if x != 2}`, "{1:1, 3:9}"},
+		{`[x for x in fn() { yield 1; yield 2; }()]`, "[1, 2]"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestComprehensionErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`[x for x in 5]`, "INTEGER is not iterable"},
+		{`[x + y for x in [1]]`, "identifier not fond: y"},
+		{`{[x]: 1 for x in [1]}`, "unusable as hash key: ARRAY"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != itm.expected {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expected, errObj.Message)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package evaluator
+
+import (
+	"strings"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+)
+
+// ContractsEnabled switches requires/ensures checking on or off for every
+// call. Turning it off skips evaluating the clauses altogether.
+var ContractsEnabled = true
+
+// checkRequires evaluates the preconditions of fn in the call's environment.
+func checkRequires(fn *object.Function, args []object.Object, env *object.Environement) object.Object {
+	if !ContractsEnabled {
+		return nil
+	}
+
+	for _, clause := range fn.Requires {
+		if err := checkClause("requires", clause, fn, args, nil, env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkEnsures evaluates the postconditions of fn with result bound to its
+// return value. They see the parameters as they were passed, not as the body
+// left them, and none of the body's locals.
+func checkEnsures(fn *object.Function, args []object.Object, result object.Object, bind func(*object.Environement)) object.Object {
+	if !ContractsEnabled || len(fn.Ensures) == 0 {
+		return nil
+	}
+	if result == nil {
+		result = NULL
+	}
+
+	resultEnv := extendFunctionEnv(fn, args)
+	if bind != nil {
+		bind(resultEnv)
+	}
+	resultEnv.Set("result", result)
+
+	for _, clause := range fn.Ensures {
+		if err := checkClause("ensures", clause, fn, args, result, resultEnv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkClause(kind string, clause ast.Expression, fn *object.Function, args []object.Object, result object.Object, env *object.Environement) object.Object {
+	ok := Eval(clause, env)
+	if IsError(ok) {
+		return ok
+	}
+	if isTruthy(ok) {
+		return nil
+	}
+
+	err := NewError("contract violation: %s %s failed for %s", kind, clause.String(), describeCall(fn, args))
+	if result != nil {
+		err.Message += " with result = " + Inspect(result)
+	}
+	return err
+}
+
+// describeCall renders a call with its argument values, e.g. sqrt(x = -1).
+func describeCall(fn *object.Function, args []object.Object) string {
+	params := []string{}
+	for i, param := range fn.Parameters {
+		params = append(params, param.Value+" = "+Inspect(args[i]))
+	}
+
+	name := fn.Name
+	if name == "" {
+		name = "fn"
+	}
+	return name + "(" + strings.Join(params, ", ") + ")"
+}
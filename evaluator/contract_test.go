@@ -0,0 +1,63 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func TestContracts(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let sq = fn(x) requires x >= 0 ensures result >= x { x * x }; sq(3)`, "9"},
+		{`fn abs(x) ensures result >= 0 { if (x < 0) { return -x; } x }; abs(-4)`, "4"},
+		{`let f = fn(x) requires x > 0 requires x < 10 { x }; f(5)`, "5"},
+		{`let f = fn() ensures !result { if (false) { 1 } }; f()`, "null"},
+		{`class C { init() { self.n = 2 } get() ensures result == self.n { self.n } }; C().get()`, "2"},
+		{`fn gen(n) requires n > 0 { yield n }; next(gen(1))`, "1"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestContractViolations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`fn sqrt(x) requires x >= 0 { x }; sqrt(-1)`, "contract violation: requires (x >= 0) failed for sqrt(x = -1)"},
+		{`let f = fn(x, y) requires x > 0 requires y > x { x }; f(2, 1)`, "contract violation: requires (y > x) failed for f(x = 2, y = 1)"},
+		{`fn neg(x) ensures result >= 0 { -x }; neg(3)`, "contract violation: ensures (result >= 0) failed for neg(x = 3) with result = -3"},
+		{`fn(s) requires len(s) > 0 { s }("")`, "contract violation: requires (len(s) > 0) failed for fn(s = )"},
+		{`fn f(x) requires y > 0 { x }; f(1)`, "identifier not fond: y"},
+		{`fn f(x) ensures result > x { x = x - 100; 0 }; f(5)`, "contract violation: ensures (result > x) failed for f(x = 5) with result = 0"},
+		{`fn f() ensures tmp == 1 { let tmp = 1; tmp }; f()`, "identifier not fond: tmp"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != itm.expected {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expected, errObj.Message)
+		}
+	}
+}
+
+func TestContractsDisabled(t *testing.T) {
+	ContractsEnabled = false
+	defer func() { ContractsEnabled = true }()
+
+	evaluated := testEval(`fn neg(x) requires x > 0 ensures result > 0 { -x }; neg(-3)`)
+	testIntegerObject(t, evaluated, 3)
+}
@@ -0,0 +1,68 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func TestDefer(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`let log = []; let f = fn() { defer log = push(log, 1); defer log = push(log, 2); log = push(log, 0); }; f(); log`,
+			"[0, 2, 1]",
+		},
+		{
+			`let log = []; let f = fn() { defer log = push(log, "deferred"); return 1; log = push(log, "unreachable"); }; f(); log`,
+			"[deferred]",
+		},
+		{
+			`let log = []; let f = fn(x) { if (x > 0) { defer log = push(log, x); } x }; f(1); f(0); f(2); log`,
+			"[1, 2]",
+		},
+		{
+			`let log = []; let f = fn() { defer log = push(log, "cleanup"); 1 + true; }; try { f(); } catch (e) { log = push(log, e.message); }; log`,
+			"[cleanup, type mismatch: INTEGER + BOOLEAN]",
+		},
+		{
+			`let log = []; let inner = fn() { defer log = push(log, "inner"); }; let outer = fn() { defer log = push(log, "outer"); inner(); }; outer(); log`,
+			"[inner, outer]",
+		},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("expect %s, got %+v", itm.expected, evaluated)
+		}
+	}
+}
+
+func TestDeferResult(t *testing.T) {
+	testIntegerObject(t, testEval(`let f = fn() { let x = 1; defer x = 2; return x; }; f()`), 1)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`defer puts(1);`, "defer outside function"},
+		{`let f = fn() { defer 1 + true; 5 }; f()`, "type mismatch: INTEGER + BOOLEAN"},
+		{`let f = fn() { defer fn() { throw "second"; }(); throw "first"; }; f()`, "first"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("expect error, got %T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != itm.expected {
+			t.Errorf("expect error %q, got %q", itm.expected, errObj.Message)
+		}
+	}
+}
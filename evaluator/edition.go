@@ -0,0 +1,32 @@
+package evaluator
+
+import (
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+)
+
+// evalUseStatement applies a pragma to the scope it runs in, normally the
+// top level of a module, so the module's functions see it wherever they are called.
+func evalUseStatement(node *ast.UseStatement, env *object.Environement) object.Object {
+	features, err := parser.ApplyPragma(currentFeatures(env), node)
+	if err != nil {
+		return withPosition(NewError("%s", err.Error()), node.Token)
+	}
+	env.SetFeatures(features)
+	return nil
+}
+
+// code without pragmas gets the latest edition
+var defaultFeatures, _ = parser.EditionFeatures(parser.LatestEdition)
+
+func currentFeatures(env *object.Environement) parser.Features {
+	if features := env.Features(); features != nil {
+		return features
+	}
+	return defaultFeatures
+}
+
+func featureEnabled(env *object.Environement, name string) bool {
+	return currentFeatures(env)[name]
+}
@@ -0,0 +1,61 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/lexer"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+	"com.language/monkey/token"
+)
+
+func TestEditionBlockScoping(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{`use edition "2024"; if (true) { let x = 1; } x`, 1},
+		{`use edition "2024"; let x = 1; if (true) { let x = 2; } x`, 2},
+		{`use edition "2024"; use feature "exceptions"; let f = fn() { try { let t = 3; } finally { } t }; f()`, 3},
+		{`use edition "2024"; use feature "block-scoping"; let x = 1; if (true) { let x = 2; } x`, 1},
+		{`use edition "2026"; let x = 1; if (true) { let x = 2; } x`, 1},
+	}
+
+	for _, itm := range tests {
+		testIntegerObject(t, testEval(itm.input), itm.expected)
+	}
+}
+
+// functions keep the features of the file that defined them
+func TestFeaturesFollowDefinitions(t *testing.T) {
+	env := object.NewEnvironment()
+	Eval(parseProgram(`use edition "2024"; let leaky = fn() { if (true) { let y = 7; } y };`), env)
+
+	caller := object.NewEnvironment()
+	leaky, _ := env.Get("leaky")
+	caller.Set("leaky", leaky)
+
+	testIntegerObject(t, Eval(parseProgram(`leaky()`), caller), 7)
+	if result := Eval(parseProgram(`if (true) { let z = 1; } z`), caller); !IsError(result) {
+		t.Errorf("expect the caller to keep block scoping, got %+v", result)
+	}
+}
+
+func TestUseStatementErrors(t *testing.T) {
+	stmt := &ast.UseStatement{
+		Token: token.Token{Type: token.USE, Literal: "use", Line: 1, Column: 1},
+		Kind:  &ast.Identifier{Value: "edition"},
+		Value: &ast.StringLiteral{Value: "1999"},
+	}
+
+	evaluated := Eval(stmt, object.NewEnvironment())
+	errObj, ok := evaluated.(*object.Error)
+	if !ok || errObj.Message != `unknown edition "1999"` {
+		t.Errorf("expect unknown edition error, got %+v", evaluated)
+	}
+}
+
+func parseProgram(input string) *ast.Program {
+	return parser.New(lexer.New(input)).ParserProgram()
+}
@@ -0,0 +1,125 @@
+package evaluator
+
+import (
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+)
+
+func evalEnumStatement(node *ast.EnumStatement, env *object.Environement) object.Object {
+	if decl, ok := env.Constant(node.Name.Value); ok {
+		return NewError("%s", parser.ConstantError("redeclare", decl))
+	}
+
+	enum := &object.Enum{Name: node.Name.Value}
+	for _, itm := range node.Variants {
+		variant := &object.EnumVariant{
+			Enum: enum,
+			Name: itm.Name.Value,
+			Unit: itm.Fields == nil,
+		}
+		for _, field := range itm.Fields {
+			variant.Fields = append(variant.Fields, field.Value)
+		}
+		enum.Variants = append(enum.Variants, variant)
+	}
+
+	env.Set(node.Name.Value, enum)
+	return nil
+}
+
+func evalEnumMember(enum *object.Enum, name string) object.Object {
+	variant, ok := enum.Variant(name)
+	if !ok {
+		return NewError("enum %s has no variant %s", enum.Name, name)
+	}
+
+	if variant.Unit {
+		return &object.EnumValue{Variant: variant}
+	}
+
+	return variant
+}
+
+func newEnumValue(variant *object.EnumVariant, args []object.Object) object.Object {
+	if len(args) != len(variant.Fields) {
+		return NewError("wrong number of arguments to %s. got %d, want %d", variant.FullName(), len(args), len(variant.Fields))
+	}
+
+	values := make([]object.Object, len(args))
+	copy(values, args)
+
+	return &object.EnumValue{Variant: variant, Values: values}
+}
+
+func evalMatchExpression(node *ast.MatchExpression, env *object.Environement) object.Object {
+	subject := Eval(node.Subject, env)
+	if IsError(subject) {
+		return subject
+	}
+
+	for _, arm := range node.Arms {
+		armEnv, err := matchPattern(arm.Pattern, subject, env)
+		if err != nil {
+			return err
+		}
+
+		if armEnv != nil {
+			return Eval(arm.Body, armEnv)
+		}
+	}
+
+	return NewError("no match arm for %s", subject.Inspect())
+}
+
+// matchPattern returns the environment holding the pattern's bindings when
+// subject matches, nil when it doesn't.
+func matchPattern(pattern ast.Expression, subject object.Object, env *object.Environement) (*object.Environement, object.Object) {
+	armEnv := object.NewEnclosedEnvironment(env)
+
+	if ident, ok := pattern.(*ast.Identifier); ok && ident.Value == "_" {
+		return armEnv, nil
+	}
+
+	// Shape.Rect(w, h) destructures instead of constructing
+	if call, ok := pattern.(*ast.CallExpression); ok {
+		function := Eval(call.Function, env)
+		if IsError(function) {
+			return nil, function
+		}
+
+		if variant, ok := function.(*object.EnumVariant); ok {
+			if len(call.Arguments) != len(variant.Fields) {
+				return nil, NewError("pattern %s has %d bindings, want %d", call.String(), len(call.Arguments), len(variant.Fields))
+			}
+
+			value, ok := subject.(*object.EnumValue)
+			if !ok || value.Variant != variant {
+				return nil, nil
+			}
+
+			for i, arg := range call.Arguments {
+				binding, ok := arg.(*ast.Identifier)
+				if !ok {
+					return nil, NewError("invalid binding %s in pattern %s", arg.String(), call.String())
+				}
+				if binding.Value != "_" {
+					armEnv.Set(binding.Value, value.Values[i])
+				}
+			}
+
+			return armEnv, nil
+		}
+	}
+
+	expected := Eval(pattern, env)
+	if IsError(expected) {
+		return nil, expected
+	}
+
+	if objectsEqual(expected, subject) {
+		return armEnv, nil
+	}
+
+	return nil, nil
+}
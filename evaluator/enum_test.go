@@ -0,0 +1,84 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+const shapes = `
+enum Shape { Circle(r), Rect(w, h), Empty }
+let area = fn(s) {
+	match (s) {
+		Shape.Circle(r) => 3 * r * r,
+		Shape.Rect(w, h) => w * h,
+		Shape.Empty => 0
+	}
+};
+`
+
+func TestEnums(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"Shape.Circle(2)", "Shape.Circle(2)"},
+		{"Shape.Rect(2, 3)", "Shape.Rect(2, 3)"},
+		{"Shape.Empty", "Shape.Empty"},
+		{"Shape", "enum Shape { Circle(r), Rect(w, h), Empty }"},
+		{"Shape.Rect(2, 3).h", 3},
+		{"Shape.Circle(2) == Shape.Circle(2)", true},
+		{"Shape.Circle(2) == Shape.Circle(3)", false},
+		{"Shape.Circle(2) != Shape.Rect(2, 2)", true},
+		{"Shape.Empty == Shape.Empty", true},
+		{"area(Shape.Circle(2))", 12},
+		{"area(Shape.Rect(2, 3))", 6},
+		{"area(Shape.Empty)", 0},
+		{"match (Shape.Rect(2, 3)) { Shape.Rect(_, h) => h, _ => 0 }", 3},
+		{"match (Shape.Rect(2, 3)) { Shape.Circle(r) => r, _ => 0 }", 0},
+		{"match (2) { 1 => 10, 2 => 20 }", 20},
+		{`match ("b") { "a" => 1, _ => 2 }`, 2},
+		{"let r = 1; match (Shape.Circle(5)) { Shape.Circle(r) => r }; r", 1},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(shapes + itm.input)
+		switch expected := itm.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBoolObject(t, evaluated, expected)
+		case string:
+			if evaluated == nil || evaluated.Inspect() != expected {
+				t.Errorf("expect %s, got %+v", expected, evaluated)
+			}
+		}
+	}
+}
+
+func TestEnumErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Shape.Triangle", "enum Shape has no variant Triangle"},
+		{"Shape.Rect(1)", "wrong number of arguments to Shape.Rect. got 1, want 2"},
+		{"Shape.Circle(1).w", "Shape.Circle has no field w"},
+		{"match (Shape.Circle(1)) { Shape.Rect(w, h) => 1 }", "no match arm for Shape.Circle(1)"},
+		{"match (Shape.Circle(1)) { Shape.Circle(r, x) => 1 }", "pattern (Shape.Circle)(r, x) has 2 bindings, want 1"},
+		{"match (Shape.Circle(1)) { Shape.Circle(1) => 1 }", "invalid binding 1 in pattern (Shape.Circle)(1)"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(shapes + itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("expect error, got %T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != itm.expected {
+			t.Errorf("expect error %q, got %q", itm.expected, errObj.Message)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"com.language/monkey/ast"
 	"com.language/monkey/object"
+	"com.language/monkey/parser"
 )
 
 var (
@@ -29,7 +30,7 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 		if IsError(right) {
 			return right
 		}
-		return evalPrefixExpression(nod.Operator, right)
+		return withPosition(evalPrefixExpression(nod.Operator, right), nod.Token)
 
 	case *ast.InFixExpression:
 		left := Eval(nod.Left, env)
@@ -41,7 +42,7 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 			return right
 		}
 
-		return evalInfixExpression(nod.Operator, left, right)
+		return withPosition(evalInfixExpression(nod.Operator, left, right), nod.Token)
 
 	case *ast.IfExpression:
 		return evalIfExpression(nod, env)
@@ -52,6 +53,9 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 		}
 		return nil
 	case *ast.ReturnStatement:
+		if nod.Value == nil {
+			return &object.ReturnValue{Value: NULL}
+		}
 		val := Eval(nod.Value, env)
 		if IsError(val) {
 			return val
@@ -59,36 +63,124 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 		return &object.ReturnValue{Value: val}
 
 	case *ast.LetStatement:
+		if decl, ok := env.Constant(nod.Name.Value); ok {
+			return NewError("%s", parser.ConstantError("redeclare", decl))
+		}
+
 		val := Eval(nod.Value, env)
 		if IsError(val) {
 			return val
 		}
-		env.Set(nod.Name.Value, val)
+		if val == nil {
+			return withPosition(NewError("let %s has no value", nod.Name.Value), nod.Name.Token)
+		}
+		if err := checkType(nod.Name.Value, nod.Type, val, env); err != nil {
+			return withPosition(err, nod.Name.Token)
+		}
+
+		if fn, ok := val.(*object.Function); ok && fn.Name == "" {
+			if _, literal := nod.Value.(*ast.FunctionLiteral); literal {
+				fn.Name = nod.Name.Value
+			}
+		}
+
+		if nod.IsConst() {
+			env.SetConst(nod.Name.Value, val, nod.Name)
+		} else {
+			env.Set(nod.Name.Value, val)
+		}
+		env.Annotate(nod.Name.Value, nod.Type)
+
+	case *ast.AssignExpression:
+		return withPosition(evalAssignExpression(nod, env), nod.Name.Token)
+
+	case *ast.MemberAssignExpression:
+		return withPosition(evalMemberAssignExpression(nod, env), nod.Target.Property.Token)
+
+	case *ast.ClassStatement:
+		return evalClassStatement(nod, env)
+
+	case *ast.UseStatement:
+		return evalUseStatement(nod, env)
+
+	case *ast.ThrowStatement:
+		return evalThrowStatement(nod, env)
+
+	case *ast.TryExpression:
+		return evalTryExpression(nod, env)
+
+	case *ast.YieldExpression:
+		return withPosition(evalYieldExpression(nod, env), nod.Token)
+
+	case *ast.ForStatement:
+		return evalForStatement(nod, env)
+
+	case *ast.RangeExpression:
+		return withPosition(evalRangeExpression(nod, env), nod.Token)
+
+	case *ast.DeferStatement:
+		if !env.Defer(nod.Call, env) {
+			return withPosition(NewError("defer outside function"), nod.Token)
+		}
+		return nil
+
+	case *ast.StructStatement:
+		return evalStructStatement(nod, env)
+
+	case *ast.EnumStatement:
+		return evalEnumStatement(nod, env)
+
+	case *ast.MatchExpression:
+		return evalMatchExpression(nod, env)
 
 	case *ast.Identifier:
-		return evalIdentifier(nod, env)
+		return withPosition(evalIdentifier(nod, env), nod.Token)
 
 	case *ast.StringLiteral:
 		return &object.String{Value: nod.Value}
 
 	case *ast.FunctionLiteral:
-		params := nod.Parameters
-		body := nod.Body
-		return &object.Function{Parameters: params, Body: body, Env: env}
+		if nod.Name == nil {
+			return newFunction(nod, env)
+		}
+		// a named function expression sees itself under its name
+		fnEnv := object.NewEnclosedEnvironment(env)
+		fn := newFunction(nod, fnEnv)
+		fnEnv.Set(nod.Name.Value, fn)
+		return fn
+
+	case *ast.FunctionStatement:
+		// already bound by hoistFunctions
+		return nil
 
 	case *ast.CallExpression:
+		if nod.Function.TokenLiteral() == "quote" {
+			if len(nod.Arguments) != 1 {
+				return NewError("wrong number of arguments to quote. got %d, want 1", len(nod.Arguments))
+			}
+			return quote(nod.Arguments[0], env)
+		}
+
 		function := Eval(nod.Function, env)
 
 		if IsError(function) {
 			return function
 		}
 
-		args := evalExpressions(nod.Arguments, env)
+		args, keywords := evalCallArguments(nod.Arguments, env)
 		if len(args) == 1 && IsError(args[0]) {
 			return args[0]
 		}
+		if len(keywords) > 0 {
+			var err object.Object
+			if args, err = matchKeywordArguments(function, args, keywords); err != nil {
+				return withPosition(err, nod.Token)
+			}
+		}
 
-		return applyFunction(function, args)
+		result := withPosition(applyFunction(function, args), nod.Token)
+		addTraceFrame(result, function, nod.Token)
+		return result
 
 	case *ast.ArrayLiteral:
 		elements := evalExpressions(nod.Elements, env)
@@ -98,6 +190,16 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 		}
 
 		return &object.Array{Elements: elements}
+	case *ast.TupleLiteral:
+		elements := evalExpressions(nod.Elements, env)
+
+		if len(elements) == 1 && IsError(elements[0]) {
+			return elements[0]
+		}
+
+		return &object.Tuple{Elements: elements}
+	case *ast.UnpackStatement:
+		return evalUnpackStatement(nod, env)
 	case *ast.IndexExpression:
 		left := Eval(nod.Left, env)
 		if IsError(left) {
@@ -109,45 +211,66 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 			return index
 		}
 
-		return evalIndexExpression(left, index)
+		return withPosition(evalIndexExpression(left, index), nod.Token)
+	case *ast.MemberExpression:
+		obj := Eval(nod.Object, env)
+		if IsError(obj) {
+			return obj
+		}
+
+		return withPosition(evalMemberExpression(obj, nod.Property.Value), nod.Property.Token)
 	case *ast.HashLiteral:
 		return evalHashLiteral(nod, env)
+	case *ast.ArrayComprehension:
+		return evalArrayComprehension(nod, env)
+	case *ast.HashComprehension:
+		return evalHashComprehension(nod, env)
 	case *ast.Program:
 		return evalProgram(nod.Statements, env)
 
 	case *ast.ExpressionStatement:
 		return Eval(nod.Expression, env)
 
+	case *ast.ImportStatement:
+		return evalImportStatement(nod, env)
+
+	case *ast.ExportStatement:
+		return evalExportStatement(nod, env)
+
+	case *ast.MacroLiteral:
+		return withPosition(NewError("macro literals must be bound by a top-level let"), nod.Token)
+
+	default:
+		return evalRegisteredNode(node, env)
 	}
 	//fmt.Fprintf(os.Stderr, "invalid expression. %v", node)
 	return nil
 }
 
 func evalHashLiteral(node *ast.HashLiteral, env *object.Environement) object.Object {
-	pairs := make(map[object.HashKey]object.HashPair)
+	hash := object.NewHash()
 
-	for keyNode, valNode := range node.Pairs {
-		key := Eval(keyNode, env)
+	for _, pairNode := range node.Pairs {
+		key := Eval(pairNode.Key, env)
 		if IsError(key) {
 			return key
 		}
 
-		hashKey, ok := key.(object.HashTable)
+		hashKey, ok := object.Hashable(key)
 		if !ok {
 			return NewError("unusable as hash key: %s", key.Type())
 		}
 
-		val := Eval(valNode, env)
+		val := Eval(pairNode.Value, env)
 
 		if IsError(val) {
 			return val
 		}
 
-		hashVal := hashKey.HashKey()
-		pairs[hashVal] = object.HashPair{Key: key, Value: val}
+		hash.Set(hashKey.HashKey(), object.HashPair{Key: key, Value: val})
 	}
 
-	return &object.Hash{Pairs: pairs}
+	return hash
 }
 
 func evalIndexExpression(left, index object.Object) object.Object {
@@ -155,13 +278,56 @@ func evalIndexExpression(left, index object.Object) object.Object {
 	case left.Type() == object.ARRAY_OBJ && index.Type() == object.INTEGER_OBJ:
 
 		return evalArrayIndexExpression(left, index)
+	case left.Type() == object.TUPLE_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalTupleIndexExpression(left, index)
 	case left.Type() == object.HASH_OBJ:
 		return evalHashIndexExpression(left, index)
+	case left.Type() == object.RANGE_OBJ:
+		return evalRangeIndexExpression(left.(*object.Range), index)
+	case left.Type() == object.INSTANCE_OBJ:
+		if result, ok := callOperatorMethod(left, "__index__", index); ok {
+			return result
+		}
+		return NewError("index operator not supported: %s", left.(*object.Instance).Class.Name)
 	default:
 		return NewError("index operator not supported: %s", left.Type())
 	}
 }
 
+func evalMemberExpression(obj object.Object, name string) object.Object {
+	switch obj := obj.(type) {
+	case *object.Module:
+		return evalModuleMember(obj, name)
+	case *object.Struct:
+		if val, ok := obj.Get(name); ok {
+			return val
+		}
+		return NewError("%s has no field %s", obj.Definition.Name, name)
+	case *object.Enum:
+		return evalEnumMember(obj, name)
+	case *object.EnumValue:
+		if val, ok := obj.Get(name); ok {
+			return val
+		}
+		return NewError("%s has no field %s", obj.Variant.FullName(), name)
+	case *object.Exception:
+		return evalExceptionMember(obj, name)
+	case *object.Instance:
+		return evalInstanceMember(obj, name)
+	case *object.Super:
+		return evalSuperMember(obj, name)
+	case *object.Generator:
+		if name == "next" {
+			return &object.Builtin{Fn: func(args ...object.Object) object.Object {
+				return Builtins["next"].Fn(append([]object.Object{obj}, args...)...)
+			}}
+		}
+		return NewError("generator has no member %s", name)
+	default:
+		return NewError("member access not supported: %s.%s", obj.Type(), name)
+	}
+}
+
 func evalArrayIndexExpression(left, index object.Object) object.Object {
 	arrayObj := left.(*object.Array)
 
@@ -181,7 +347,10 @@ func evalHashIndexExpression(left, index object.Object) object.Object {
 	if !ok {
 		return NewError("expect HASH object, got %T", left)
 	}
-	key, ok := index.(object.HashTable)
+	if result, ok := evalOverloadedIndexExpression(hashObj, index); ok {
+		return result
+	}
+	key, ok := object.Hashable(index)
 	if !ok {
 		return NewError("expect HashTable object, got %T", index)
 	}
@@ -198,27 +367,55 @@ func applyFunction(fn object.Object, args []object.Object) object.Object {
 
 	switch function := fn.(type) {
 	case *object.Function:
-		extendEnv := extendFunctionEnv(function, args)
-		evaluated := Eval(function.Body, extendEnv)
-		return unwrapReturnValue(evaluated)
+		return callFunction(function, args, nil)
+
+	case *object.BoundMethod:
+		return callMethod(function, args)
+
+	case *object.Class:
+		return newInstance(function, args)
 
 	case *object.Builtin:
 		return function.Fn(args...)
+
+	case *object.StructType:
+		return newStruct(function, args)
+
+	case *object.EnumVariant:
+		return newEnumValue(function, args)
 	default:
 		return NewError("not a function: %s", fn.Type())
 	}
 }
 
 func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Environement {
-	env := object.NewEnclosedEnvironment(fn.Env)
+	env := object.NewFunctionEnvironment(fn.Env)
 
 	for idx, param := range fn.Parameters {
 		env.Set(param.Value, args[idx])
+		if idx < len(fn.ParameterTypes) {
+			env.Annotate(param.Value, fn.ParameterTypes[idx])
+		}
 	}
 
 	return env
 }
 
+// runDeferred runs the calls deferred in env last-in first-out. An error in
+// a deferred call replaces the function's result unless that already is an error.
+func runDeferred(env *object.Environement, result object.Object) object.Object {
+	deferred := env.Deferred()
+
+	for i := len(deferred) - 1; i >= 0; i-- {
+		val := Eval(deferred[i].Call, deferred[i].Env)
+		if IsError(val) && !IsError(result) {
+			result = val
+		}
+	}
+
+	return result
+}
+
 func unwrapReturnValue(obj object.Object) object.Object {
 	if returnVal, ok := obj.(*object.ReturnValue); ok {
 		return returnVal.Value
@@ -242,6 +439,12 @@ func evalExpressions(exps []ast.Expression, env *object.Environement) []object.O
 }
 
 func evalPrefixExpression(operator string, rightNode object.Object) object.Object {
+	if fn, ok := registeredPrefixOperator(operator); ok {
+		if result := fn(rightNode); result != nil {
+			return result
+		}
+	}
+
 	switch operator {
 	case "!":
 		return evalBangOperatorExpression(rightNode)
@@ -276,12 +479,31 @@ func evalMinusPrefixOperationExpression(node object.Object) object.Object {
 }
 
 func evalInfixExpression(operator string, left, right object.Object) object.Object {
+	if fn, ok := registeredInfixOperator(operator); ok {
+		if result := fn(left, right); result != nil {
+			return result
+		}
+	}
+
+	if result, ok := evalOverloadedInfixExpression(operator, left, right); ok {
+		return result
+	}
 
 	switch {
+	case operator == "in":
+		return evalInExpression(left, right)
 	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
 		return evalIntegerInfixExpression(operator, left, right)
 	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
 		return evalStringinfixExpression(operator, left, right)
+	case left.Type() == object.STRUCT_OBJ && right.Type() == object.STRUCT_OBJ:
+		return evalStructuralInfixExpression(operator, left, right)
+	case left.Type() == object.ENUM_VALUE_OBJ && right.Type() == object.ENUM_VALUE_OBJ:
+		return evalStructuralInfixExpression(operator, left, right)
+	case left.Type() == object.RANGE_OBJ && right.Type() == object.RANGE_OBJ:
+		return evalStructuralInfixExpression(operator, left, right)
+	case left.Type() == object.TUPLE_OBJ && right.Type() == object.TUPLE_OBJ:
+		return evalStructuralInfixExpression(operator, left, right)
 	case operator == "==":
 		return nativeBooltoToBooleanObject(left == right)
 	case operator == "!=":
@@ -311,6 +533,10 @@ func evalIntegerInfixExpression(operator string, left, right object.Object) obje
 		return nativeBooltoToBooleanObject(leftVal < rightVal)
 	case ">":
 		return nativeBooltoToBooleanObject(leftVal > rightVal)
+	case "<=":
+		return nativeBooltoToBooleanObject(leftVal <= rightVal)
+	case ">=":
+		return nativeBooltoToBooleanObject(leftVal >= rightVal)
 	case "==":
 		return nativeBooltoToBooleanObject(leftVal == rightVal)
 	case "!=":
@@ -340,10 +566,10 @@ func evalIfExpression(node *ast.IfExpression, env *object.Environement) object.O
 		return condition
 	}
 	if isTruthy(condition) {
-		return Eval(node.Consequence, env)
+		return evalScopedBlock(node.Consequence, env)
 	} else if !isTruthy(condition) {
 		if node.Alternative != nil {
-			return Eval(node.Alternative, env)
+			return evalScopedBlock(node.Alternative, env)
 		} else {
 			return NULL
 		}
@@ -352,6 +578,39 @@ func evalIfExpression(node *ast.IfExpression, env *object.Environement) object.O
 	}
 }
 
+func evalAssignExpression(node *ast.AssignExpression, env *object.Environement) object.Object {
+	name := node.Name.Value
+
+	scope, ok := env.Resolve(name)
+	if !ok {
+		return NewError("cannot assign to undeclared identifier: %s", name)
+	}
+
+	if decl, ok := scope.Constant(name); ok {
+		return NewError("%s", parser.ConstantError("assign to", decl))
+	}
+
+	val := Eval(node.Value, env)
+	if IsError(val) {
+		return val
+	}
+
+	if node.Operator != "" {
+		current, _ := scope.Get(name)
+		val = evalInfixExpression(node.Operator, current, val)
+		if IsError(val) {
+			return val
+		}
+	}
+
+	// an annotated binding keeps its type
+	if err := checkType(name, scope.Annotation(name), val, env); err != nil {
+		return err
+	}
+
+	return scope.Set(name, val)
+}
+
 func evalIdentifier(node *ast.Identifier, env *object.Environement) object.Object {
 	if val, ok := env.Get(node.Value); ok {
 		return val
@@ -398,6 +657,10 @@ func evalProgram(stmts []ast.Statement, env *object.Environement) object.Object
 
 	var obj object.Object
 
+	if err := hoistFunctions(stmts, env); err != nil {
+		return err
+	}
+
 	for _, itm := range stmts {
 		obj = Eval(itm, env)
 
@@ -415,9 +678,22 @@ func evalProgram(stmts []ast.Statement, env *object.Environement) object.Object
 	return obj
 }
 
+// evalScopedBlock runs block in its own scope, so names it declares are
+// gone once it finishes.
+func evalScopedBlock(block *ast.BlockStatements, env *object.Environement) object.Object {
+	if !featureEnabled(env, "block-scoping") {
+		return Eval(block, env)
+	}
+	return Eval(block, object.NewEnclosedEnvironment(env))
+}
+
 func evalBlockStatements(stmts []ast.Statement, env *object.Environement) object.Object {
 	var ret object.Object
 
+	if err := hoistFunctions(stmts, env); err != nil {
+		return err
+	}
+
 	for _, stmt := range stmts {
 		ret = Eval(stmt, env)
 
@@ -173,6 +173,10 @@ func TestErrorHandling(t *testing.T) {
 			`"hello" - "world"`,
 			"unknow operator:STRING - STRING",
 		},
+		{
+			"let x = fn() {}(); x",
+			"let x has no value",
+		},
 	}
 
 	for _, itm := range tests {
@@ -523,3 +527,152 @@ func testBoolObject(t *testing.T, obj object.Object, expect bool) bool {
 	}
 	return true
 }
+
+func TestAssignExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let a = 5; a = 10; a;", 10},
+		{"let a = 5; let b = a = 7; a + b;", 14},
+		{"let a = 1; let inc = fn() { a = a + 1; }; inc(); inc(); a;", 3},
+		{"let a = 1; let f = fn(a) { a = 5; }; f(2); a;", 1},
+		{"const a = 1; let f = fn() { let a = 2; a = 3; a; }; f();", 3},
+	}
+
+	for _, itm := range tests {
+		testIntegerObject(t, testEval(itm.input), itm.expected)
+	}
+}
+
+func TestBlockScoping(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"if (true) { let x = 1; } x", "identifier not fond: x"},
+		{"if (false) { 1 } else { let y = 2; } y", "identifier not fond: y"},
+		{"let x = 1; if (true) { let x = 2; } x", 1},
+		{"let x = 1; if (true) { x = 2; } x", 2},
+		{"let x = 1; if (true) { let x = 5; x = 6; } x", 1},
+		{"let f = fn() { if (true) { let a = 10; } a }; f()", "identifier not fond: a"},
+		{"let f = fn() { let n = 0; if (true) { n = n + 1; if (true) { n = n + 1; } } n }; f()", 2},
+		{"let f = fn() { if (true) { let inner = 3; return fn() { inner }; } }; f()()", 3},
+		{"try { let t = 1; } finally { } t", "identifier not fond: t"},
+		{"if (true) { const c = 1; } let c = 2; c", 2},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		switch expected := itm.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("%s: expect error, got %T (%+v)", itm.input, evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("%s: expect %q, got %q", itm.input, expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestConstStatements(t *testing.T) {
+	testIntegerObject(t, testEval("const a = 5; const b = a * 2; b;"), 10)
+
+	// each line is parsed on its own, like in the REPL, so only the
+	// environment can catch these
+	tests := []struct {
+		inputs   []string
+		expected string
+	}{
+		{
+			[]string{"const a = 5;", "a = 6;"},
+			"cannot assign to constant a (declared at line 1, column 7)",
+		},
+		{
+			[]string{"const a = 5;", "let a = 6;"},
+			"cannot redeclare constant a (declared at line 1, column 7)",
+		},
+		{
+			[]string{"\n  const a = 5;", "const a = 6;"},
+			"cannot redeclare constant a (declared at line 2, column 9)",
+		},
+		{
+			[]string{"const a = 5;", "let f = fn() { a = 6; };", "f();"},
+			"cannot assign to constant a (declared at line 1, column 7)",
+		},
+		{
+			[]string{"b = 1;"},
+			"cannot assign to undeclared identifier: b",
+		},
+	}
+
+	for _, itm := range tests {
+		env := object.NewEnvironment()
+		var evaluated object.Object
+		for _, input := range itm.inputs {
+			program := parser.New(lexer.New(input)).ParserProgram()
+			evaluated = Eval(program, env)
+		}
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("expect error, got %T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != itm.expected {
+			t.Errorf("expect error %q, got %q", itm.expected, errObj.Message)
+		}
+	}
+}
+
+func TestHashOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"b": 1, "a": 2, "c": 3}`, "{b:1, a:2, c:3}"},
+		{`{3: "x", 1: "y", 2: "z"}`, "{3:x, 1:y, 2:z}"},
+		{`{"a": 1, "b": 2, "a": 3}`, "{a:3, b:2}"},
+		{`let log = []; let f = fn(x) { log = push(log, x); x }; {f(1): f(2), f(3): f(4), f(5): f(6)}; log`, "[1, 2, 3, 4, 5, 6]"},
+		{`let keys = []; for k in {"z": 1, "y": 2, "x": 3} { keys = push(keys, k); }; keys`, "[z, y, x]"},
+	}
+
+	for _, itm := range tests {
+		// repeat to catch map iteration order leaking through
+		for i := 0; i < 10; i++ {
+			evaluated := testEval(itm.input)
+			if evaluated == nil || evaluated.Inspect() != itm.expected {
+				t.Errorf("expect %s, got %+v", itm.expected, evaluated)
+				break
+			}
+		}
+	}
+}
+
+func TestOptionalSemicolons(t *testing.T) {
+	input := `
+let add = fn(a, b,) {
+  let sum = a + b
+  return sum
+}
+let xs = [
+  add(1, 2,),
+  add(3, 4),
+]
+let early = fn() {
+  return
+  1
+}
+[xs, early()]
+`
+	evaluated := testEval(input)
+	if evaluated == nil || evaluated.Inspect() != "[[3, 7], null]" {
+		t.Errorf("expect [[3, 7], null], got %+v", evaluated)
+	}
+}
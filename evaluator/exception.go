@@ -0,0 +1,92 @@
+package evaluator
+
+import (
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+	"com.language/monkey/token"
+)
+
+func evalThrowStatement(node *ast.ThrowStatement, env *object.Environement) object.Object {
+	val := Eval(node.Value, env)
+	if IsError(val) {
+		return val
+	}
+
+	// rethrowing a caught exception keeps its message and origin
+	if exception, ok := val.(*object.Exception); ok {
+		return &object.Error{
+			Message: exception.Message,
+			Value:   exception.Value,
+			Line:    exception.Line,
+			Column:  exception.Column,
+		}
+	}
+
+	message := val.Inspect()
+	if str, ok := val.(*object.String); ok {
+		message = str.Value
+	}
+
+	return withPosition(&object.Error{Message: message, Value: val}, node.Token)
+}
+
+func evalTryExpression(node *ast.TryExpression, env *object.Environement) object.Object {
+	result := evalScopedBlock(node.Block, env)
+
+	if errObj, ok := result.(*object.Error); ok && node.Catch != nil && !isGeneratorStop(errObj) {
+		catchEnv := object.NewEnclosedEnvironment(env)
+		if node.Parameter != nil {
+			catchEnv.Set(node.Parameter.Value, newException(errObj))
+		}
+		result = Eval(node.Catch, catchEnv)
+	}
+
+	if node.Finally != nil {
+		// an error or return in finally replaces whatever try/catch produced
+		final := evalScopedBlock(node.Finally, env)
+		if IsError(final) || (final != nil && final.Type() == object.RETURN_VALUE_OBJ) {
+			return final
+		}
+	}
+
+	return result
+}
+
+func newException(err *object.Error) *object.Exception {
+	value := err.Value
+	if value == nil {
+		value = &object.String{Value: err.Message}
+	}
+
+	return &object.Exception{
+		Message: err.Message,
+		Value:   value,
+		Line:    err.Line,
+		Column:  err.Column,
+	}
+}
+
+func evalExceptionMember(exception *object.Exception, name string) object.Object {
+	switch name {
+	case "message":
+		return &object.String{Value: exception.Message}
+	case "value":
+		return exception.Value
+	case "line":
+		return &object.Integer{Value: int64(exception.Line)}
+	case "column":
+		return &object.Integer{Value: int64(exception.Column)}
+	default:
+		return NewError("exception has no field %s", name)
+	}
+}
+
+// withPosition records where an error surfaced first; errors that already
+// carry a position are left alone as they unwind through enclosing nodes.
+func withPosition(obj object.Object, tok token.Token) object.Object {
+	if errObj, ok := obj.(*object.Error); ok && errObj.Line == 0 {
+		errObj.Line = tok.Line
+		errObj.Column = tok.Column
+	}
+	return obj
+}
@@ -0,0 +1,74 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func TestTryCatch(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`try { 1 } catch (e) { 2 }`, 1},
+		{`try { throw "boom"; 1 } catch (e) { 2 }`, 2},
+		{`try { throw "boom"; } catch (e) { e.message }`, "boom"},
+		{`try { throw 42; } catch (e) { e.value }`, 42},
+		{`try { throw 42; } catch (e) { e.message }`, "42"},
+		{`try { 1 + true } catch (e) { e.message }`, "type mismatch: INTEGER + BOOLEAN"},
+		{`try { len(1) } catch (e) { e.message }`, "argument to `len` not support. got INTEGER"},
+		{`try { len(1) } catch (e) { e.value }`, "argument to `len` not support. got INTEGER"},
+		{"try {\n  throw \"boom\";\n} catch (e) { e.line }", 2},
+		{"try {\n  throw \"boom\";\n} catch (e) { e.column }", 3},
+		{"try {\n  let x = 1;\n  x + nothing;\n} catch (e) { e.column }", 7},
+		{`let f = fn() { throw "inner"; }; try { f(); } catch (e) { e.message }`, "inner"},
+		{`try { try { throw "a"; } catch (e) { throw e; } } catch (e) { e.message }`, "a"},
+		{`try { try { throw "a"; } finally { 1 } } catch (e) { e.message }`, "a"},
+		{`let x = 0; try { x = 1; } finally { x = x + 10; }; x`, 11},
+		{`let x = 0; try { throw 1; } catch { x = 5; } finally { x = x * 2; }; x`, 10},
+		{`let f = fn() { try { return 1; } finally { 2; } }; f()`, 1},
+		{`let f = fn() { try { return 1; } finally { return 2; } }; f()`, 2},
+		{`try { throw "x"; } catch (e) { 1 }; let e = 3; e`, 3},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		switch expected := itm.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			str, ok := evaluated.(*object.String)
+			if !ok {
+				t.Errorf("%s: expect string, got %T (%+v)", itm.input, evaluated, evaluated)
+				continue
+			}
+			if str.Value != expected {
+				t.Errorf("expect %q, got %q", expected, str.Value)
+			}
+		}
+	}
+}
+
+func TestUncaughtThrow(t *testing.T) {
+	evaluated := testEval("let a = 1;\nthrow \"boom\";\na;")
+
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("expect error, got %T (%+v)", evaluated, evaluated)
+	}
+
+	if errObj.Message != "boom" {
+		t.Errorf("expect boom, got %s", errObj.Message)
+	}
+
+	if errObj.Inspect() != "ERROR: boom (line 2, column 1)" {
+		t.Errorf("expect position in Inspect, got %s", errObj.Inspect())
+	}
+
+	evaluated = testEval(`try { throw 1; } finally { throw "again"; }`)
+	errObj, ok = evaluated.(*object.Error)
+	if !ok || errObj.Message != "again" {
+		t.Errorf("expect error from finally, got %+v", evaluated)
+	}
+}
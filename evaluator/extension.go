@@ -0,0 +1,103 @@
+package evaluator
+
+import (
+	"reflect"
+	"sync"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+)
+
+// Evaluator hooks matching the parser extension API. Unlike parser
+// extensions, which belong to one Parser, the hooks are process-wide: a
+// registered operator applies to every evaluation until it is unregistered.
+// Registering is safe while other goroutines evaluate.
+
+// NodeEvaluator evaluates an AST node type the evaluator does not know about.
+type NodeEvaluator func(node ast.Node, env *object.Environement) object.Object
+
+type InfixOperator func(left, right object.Object) object.Object
+
+type PrefixOperator func(right object.Object) object.Object
+
+var (
+	hooks           sync.RWMutex
+	nodeEvaluators  = map[reflect.Type]NodeEvaluator{}
+	infixOperators  = map[string]InfixOperator{}
+	prefixOperators = map[string]PrefixOperator{}
+)
+
+// RegisterNode makes Eval call fn for every node with the same concrete type as node, e.g.
+//
+//	evaluator.RegisterNode(&PipeExpression{}, evalPipe)
+func RegisterNode(node ast.Node, fn NodeEvaluator) {
+	hooks.Lock()
+	defer hooks.Unlock()
+	nodeEvaluators[reflect.TypeOf(node)] = fn
+}
+
+// UnregisterNode removes the evaluator registered for the type of node.
+func UnregisterNode(node ast.Node) {
+	hooks.Lock()
+	defer hooks.Unlock()
+	delete(nodeEvaluators, reflect.TypeOf(node))
+}
+
+// RegisterInfixOperator evaluates operator in infix expressions. It is
+// consulted before the built-in operators; returning nil falls back to them.
+func RegisterInfixOperator(operator string, fn InfixOperator) {
+	hooks.Lock()
+	defer hooks.Unlock()
+	infixOperators[operator] = fn
+}
+
+// UnregisterInfixOperator removes the hook registered for operator.
+func UnregisterInfixOperator(operator string) {
+	hooks.Lock()
+	defer hooks.Unlock()
+	delete(infixOperators, operator)
+}
+
+// RegisterPrefixOperator evaluates operator in prefix expressions. It is
+// consulted before the built-in operators; returning nil falls back to them.
+func RegisterPrefixOperator(operator string, fn PrefixOperator) {
+	hooks.Lock()
+	defer hooks.Unlock()
+	prefixOperators[operator] = fn
+}
+
+// UnregisterPrefixOperator removes the hook registered for operator.
+func UnregisterPrefixOperator(operator string) {
+	hooks.Lock()
+	defer hooks.Unlock()
+	delete(prefixOperators, operator)
+}
+
+func evalRegisteredNode(node ast.Node, env *object.Environement) object.Object {
+	hooks.RLock()
+	fn, ok := nodeEvaluators[reflect.TypeOf(node)]
+	hooks.RUnlock()
+	if !ok {
+		return nil
+	}
+	return fn(node, env)
+}
+
+func registeredInfixOperator(operator string) (InfixOperator, bool) {
+	hooks.RLock()
+	defer hooks.RUnlock()
+	fn, ok := infixOperators[operator]
+	return fn, ok
+}
+
+func registeredPrefixOperator(operator string) (PrefixOperator, bool) {
+	hooks.RLock()
+	defer hooks.RUnlock()
+	fn, ok := prefixOperators[operator]
+	return fn, ok
+}
+
+// ApplyFunction calls a Monkey function, builtin or constructor with args.
+func ApplyFunction(fn object.Object, args []object.Object) object.Object {
+	return applyFunction(fn, args)
+}
@@ -0,0 +1,138 @@
+package evaluator
+
+import (
+	"bytes"
+	"testing"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/lexer"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+	"com.language/monkey/token"
+)
+
+// unless (cond) { ... }
+type unlessExpression struct {
+	ast.ExpressionNode
+	Token token.Token
+	Cond  ast.Expression
+	Body  *ast.BlockStatements
+}
+
+func (u *unlessExpression) TokenLiteral() string { return u.Token.Literal }
+func (u *unlessExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("unless")
+	out.WriteString(u.Cond.String())
+	out.WriteString(" ")
+	out.WriteString(u.Body.String())
+	return out.String()
+}
+
+func testEvalExtended(input string) object.Object {
+	p := parser.New(lexer.New(input))
+	p.RegisterToken("|>", "|>")
+	p.RegisterToken("unless", "UNLESS")
+	p.RegisterInfixOperator("|>", parser.EQUALS+1)
+	p.RegisterPrefix("UNLESS", func() ast.Expression {
+		exp := &unlessExpression{Token: p.CurToken()}
+		if !p.ExpectPeek("(") {
+			return nil
+		}
+		p.NextToken()
+		exp.Cond = p.ParseExpression(parser.LOWEST)
+		if !p.ExpectPeek(")") || !p.ExpectPeek("{") {
+			return nil
+		}
+		exp.Body = p.ParseBlockStatements()
+		return exp
+	})
+
+	program := p.ParserProgram()
+	if len(p.Errors()) != 0 {
+		return NewError("parse errors: %v", p.Errors())
+	}
+	return Eval(program, object.NewEnvironment())
+}
+
+func TestExtensionHooks(t *testing.T) {
+	RegisterInfixOperator("|>", func(left, right object.Object) object.Object {
+		return ApplyFunction(right, []object.Object{left})
+	})
+	RegisterNode(&unlessExpression{}, func(node ast.Node, env *object.Environement) object.Object {
+		unless := node.(*unlessExpression)
+		cond := Eval(unless.Cond, env)
+		if IsError(cond) {
+			return cond
+		}
+		if isTruthy(cond) {
+			return NULL
+		}
+		return Eval(unless.Body, env)
+	})
+	defer UnregisterInfixOperator("|>")
+	defer UnregisterNode(&unlessExpression{})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let double = fn(x) { x * 2 }; 3 |> double |> double`, "12"},
+		{`[1, 2] |> len`, "2"},
+		{`unless (false) { 10 }`, "10"},
+		{`unless (1 > 0) { 10 }`, "null"},
+		{`let x = 5; unless (x > 10) { x |> fn(n) { n + 1 } }`, "6"},
+		{`1 |> 2`, "ERROR: not a function: INTEGER (line 1, column 3)"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEvalExtended(itm.input)
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestPrefixOperatorHookFallsBack(t *testing.T) {
+	RegisterPrefixOperator("-", func(right object.Object) object.Object {
+		if str, ok := right.(*object.String); ok {
+			reversed := []byte{}
+			for i := len(str.Value) - 1; i >= 0; i-- {
+				reversed = append(reversed, str.Value[i])
+			}
+			return &object.String{Value: string(reversed)}
+		}
+		return nil
+	})
+	defer UnregisterPrefixOperator("-")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`-"abc"`, "cba"},
+		{`-5`, "-5"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestUnregisterHooks(t *testing.T) {
+	RegisterInfixOperator("|>", func(left, right object.Object) object.Object {
+		return ApplyFunction(right, []object.Object{left})
+	})
+	RegisterPrefixOperator("-", func(right object.Object) object.Object { return TRUE })
+
+	UnregisterInfixOperator("|>")
+	UnregisterPrefixOperator("-")
+
+	if evaluated := testEvalExtended(`1 |> len`); !IsError(evaluated) {
+		t.Errorf("expect |> to be unknown again, got %+v", evaluated)
+	}
+	testIntegerObject(t, testEval(`-5`), -5)
+}
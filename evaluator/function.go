@@ -0,0 +1,221 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+	"com.language/monkey/token"
+)
+
+func newFunction(node *ast.FunctionLiteral, env *object.Environement) *object.Function {
+	fn := &object.Function{
+		Parameters:     node.Parameters,
+		ParameterTypes: node.ParameterTypes,
+		ReturnType:     node.ReturnType,
+		Requires:       node.Requires,
+		Ensures:        node.Ensures,
+		Body:           node.Body,
+		Env:            env,
+		IsGenerator:    node.IsGenerator,
+	}
+	if node.Name != nil {
+		fn.Name = node.Name.Value
+	}
+	return fn
+}
+
+// hoistFunctions binds every `fn name() {}` of stmts before any of them
+// runs, so declarations can refer to each other regardless of order.
+func hoistFunctions(stmts []ast.Statement, env *object.Environement) object.Object {
+	for _, stmt := range stmts {
+		decl, ok := stmt.(*ast.FunctionStatement)
+		if !ok {
+			continue
+		}
+
+		name := decl.Function.Name
+		if constant, ok := env.Constant(name.Value); ok {
+			return withPosition(NewError("%s", parser.ConstantError("redeclare", constant)), name.Token)
+		}
+		env.Set(name.Value, newFunction(decl.Function, env))
+	}
+	return nil
+}
+
+// callFunction runs fn with args. bind, when set, adds names such as self
+// to the call's environment before the body runs.
+func callFunction(fn *object.Function, args []object.Object, bind func(*object.Environement)) object.Object {
+	if len(args) != len(fn.Parameters) {
+		return arityError(fn, len(args))
+	}
+	if err := checkArgumentTypes(fn, args); err != nil {
+		return err
+	}
+
+	extendEnv := extendFunctionEnv(fn, args)
+	if bind != nil {
+		bind(extendEnv)
+	}
+	if err := checkRequires(fn, args, extendEnv); err != nil {
+		return err
+	}
+	if fn.IsGenerator {
+		gen := newGenerator(fn, extendEnv)
+		if err := checkReturnType(fn, gen); err != nil {
+			gen.Stop()
+			return err
+		}
+		return gen
+	}
+
+	evaluated := Eval(fn.Body, extendEnv)
+	evaluated = unwrapReturnValue(runDeferred(extendEnv, evaluated))
+	if IsError(evaluated) {
+		return evaluated
+	}
+	if err := checkReturnType(fn, evaluated); err != nil {
+		return err
+	}
+	if err := checkEnsures(fn, args, evaluated, bind); err != nil {
+		return err
+	}
+	return evaluated
+}
+
+func arityError(fn *object.Function, got int) *object.Error {
+	if fn.Name == "" {
+		return NewError("wrong number of arguments. got %d, want %d", got, len(fn.Parameters))
+	}
+	return NewError("wrong number of arguments to %s. got %d, want %d", fn.Name, got, len(fn.Parameters))
+}
+
+// addTraceFrame records the call of callee at tok on an error unwinding through it.
+func addTraceFrame(result object.Object, callee object.Object, tok token.Token) {
+	errObj, ok := result.(*object.Error)
+	if !ok {
+		return
+	}
+
+	var name string
+	switch callee := callee.(type) {
+	case *object.Function:
+		name = callee.Name
+		if name == "" {
+			name = "<anonymous>"
+		}
+	case *object.BoundMethod:
+		name = callee.Owner.Name + "." + callee.Method.Name
+	case *object.Class:
+		name = callee.Name
+	default:
+		return
+	}
+	errObj.Trace = append(errObj.Trace, fmt.Sprintf("%s (line %d, column %d)", name, tok.Line, tok.Column))
+}
+
+type keywordArgument struct {
+	name  string
+	value object.Object
+}
+
+// evalCallArguments evaluates the arguments of a call in order, returning the
+// keyword arguments separately. An error is returned as the only positional value.
+func evalCallArguments(exps []ast.Expression, env *object.Environement) ([]object.Object, []keywordArgument) {
+	var args []object.Object
+	var keywords []keywordArgument
+
+	for _, exp := range exps {
+		keyword, ok := exp.(*ast.KeywordArgument)
+		if ok {
+			exp = keyword.Value
+		}
+
+		obj := Eval(exp, env)
+		if IsError(obj) {
+			return []object.Object{obj}, nil
+		}
+
+		if ok {
+			keywords = append(keywords, keywordArgument{name: keyword.Name.Value, value: obj})
+		} else {
+			args = append(args, obj)
+		}
+	}
+	return args, keywords
+}
+
+// matchKeywordArguments places each keyword argument at the position of the
+// parameter it names, so the callee receives plain positional arguments.
+func matchKeywordArguments(callee object.Object, args []object.Object, keywords []keywordArgument) ([]object.Object, object.Object) {
+	var params []string
+	var name string
+
+	switch callee := callee.(type) {
+	case *object.Function:
+		params, name = parameterNames(callee), callee.Name
+	case *object.BoundMethod:
+		params, name = parameterNames(callee.Method), callee.Owner.Name+"."+callee.Method.Name
+	case *object.Class:
+		if init, _ := callee.Lookup("init"); init != nil {
+			params = parameterNames(init)
+		}
+		name = callee.Name
+	case *object.StructType:
+		params, name = callee.Fields, callee.Name
+	default:
+		return nil, NewError("keyword arguments not supported: %s", callee.Type())
+	}
+
+	// too many positional arguments; let the callee report its arity
+	if len(args) > len(params) {
+		for _, keyword := range keywords {
+			args = append(args, keyword.value)
+		}
+		return args, nil
+	}
+
+	matched := make([]object.Object, len(params))
+	copy(matched, args)
+
+	for _, keyword := range keywords {
+		idx := -1
+		for i, param := range params {
+			if param == keyword.name {
+				idx = i
+				break
+			}
+		}
+
+		switch {
+		case idx < 0:
+			return nil, NewError("unknown keyword argument %s%s", keyword.name, calleeSuffix(name))
+		case matched[idx] != nil:
+			return nil, NewError("duplicate argument %s%s", keyword.name, calleeSuffix(name))
+		}
+		matched[idx] = keyword.value
+	}
+
+	for i, arg := range matched {
+		if arg == nil {
+			return nil, NewError("missing argument %s%s", params[i], calleeSuffix(name))
+		}
+	}
+	return matched, nil
+}
+
+func parameterNames(fn *object.Function) []string {
+	names := make([]string, len(fn.Parameters))
+	for i, param := range fn.Parameters {
+		names[i] = param.Value
+	}
+	return names
+}
+
+func calleeSuffix(name string) string {
+	if name == "" {
+		return ""
+	}
+	return " to " + name
+}
@@ -0,0 +1,148 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func TestFunctionDeclarations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`fn fact(n) { if (n == 0) { return 1; } n * fact(n - 1) } fact(5)`, "120"},
+		{`isEven(10); fn isEven(n) { if (n == 0) { true } else { isOdd(n - 1) } } fn isOdd(n) { if (n == 0) { false } else { isEven(n - 1) } } isEven(7)`, "false"},
+		{`let f = fn() { return helper() * 2; fn helper() { 21 } }; f()`, "42"},
+		{`if (true) { fn local() { 1 } } local`, "ERROR: identifier not fond: local (line 1, column 32)"},
+		{`fn fact(n) { n }`, "null"},
+		{`fn fact(n) { n } fact`, "<fn fact/1>"},
+		{`let add = fn(x, y) { x + y }; add`, "<fn add/2>"},
+		{`fn(x) { x }`, "<fn/1>"},
+		{`let f = fn named() { 1 }; f`, "<fn named/0>"},
+		{`let f = fn inner(n) { if (n < 1) { 0 } else { n + inner(n - 1) } }; f(3)`, "6"},
+		{`let f = fn inner() { 1 }; inner`, "ERROR: identifier not fond: inner (line 1, column 27)"},
+		{`let g = fn() { 1 }; let h = g; h`, "<fn g/0>"},
+		{`[fn() { 1 }]`, "[<fn/0>]"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		if evaluated == nil && itm.expected == "null" {
+			continue
+		}
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestFunctionErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		trace    []string
+	}{
+		{
+			`fn add(x, y) { x + y } add(1)`,
+			"wrong number of arguments to add. got 1, want 2",
+			[]string{"add (line 1, column 27)"},
+		},
+		{
+			`fn(x) { x }(1, 2)`,
+			"wrong number of arguments. got 2, want 1",
+			[]string{"<anonymous> (line 1, column 12)"},
+		},
+		{
+			"fn boom(x) { x + \"a\" }\nfn outer() { boom(1) }\nouter();",
+			"type mismatch: INTEGER + STRING",
+			[]string{"boom (line 2, column 18)", "outer (line 3, column 6)"},
+		},
+		{
+			`let f = fn() { throw "bad"; }; fn() { f() }()`,
+			"bad",
+			[]string{"f (line 1, column 40)", "<anonymous> (line 1, column 44)"},
+		},
+		{
+			`const f = 1; let g = fn() { fn f() { 2 } }; g()`,
+			"",
+			nil,
+		},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		if itm.expected == "" {
+			if IsError(evaluated) {
+				t.Errorf("%s: unexpected error %s", itm.input, evaluated.Inspect())
+			}
+			continue
+		}
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != itm.expected {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expected, errObj.Message)
+		}
+		if len(errObj.Trace) != len(itm.trace) {
+			t.Errorf("%s: expect trace %v, got %v", itm.input, itm.trace, errObj.Trace)
+			continue
+		}
+		for i, frame := range itm.trace {
+			if errObj.Trace[i] != frame {
+				t.Errorf("%s: expect frame %q, got %q", itm.input, frame, errObj.Trace[i])
+			}
+		}
+	}
+}
+
+func TestKeywordArguments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`fn request(url, timeout, retry) { [url, timeout, retry] }; request("u", timeout: 30, retry: true)`, "[u, 30, true]"},
+		{`fn request(url, timeout, retry) { [url, timeout, retry] }; request(retry: false, url: "u", timeout: 5)`, "[u, 5, false]"},
+		{`let sub = fn(a, b) { a - b }; sub(b: 1, a: 10)`, "9"},
+		{`class P { init(x, y) { self.x = x; self.y = y } dist(dx, dy) { self.x + dx - self.y - dy } }; P(y: 2, x: 5).dist(dy: 1, dx: 0)`, "2"},
+		{`struct Point { x, y }; Point(y: 2, x: 1)`, "Point{x: 1, y: 2}"},
+		{`fn f(x: int) { x }; f(x: 4)`, "4"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		if evaluated == nil || Inspect(evaluated) != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestKeywordArgumentErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`fn f(a, b) { a }; f(1, c: 2)`, "unknown keyword argument c to f"},
+		{`fn f(a, b) { a }; f(1, a: 2)`, "duplicate argument a to f"},
+		{`fn f(a, b, c) { a }; f(1, c: 2)`, "missing argument b to f"},
+		{`fn(a) { a }(b: 1)`, "unknown keyword argument b"},
+		{`fn f(a) { a }; f(1, 2, a: 3)`, "wrong number of arguments to f. got 3, want 1"},
+		{`len(s: "abc")`, "keyword arguments not supported: BUILTIN"},
+		{`class C { m(x) { x } }; C().m(y: 1)`, "unknown keyword argument y to C.m"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != itm.expected {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expected, errObj.Message)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package evaluator
+
+import (
+	"runtime"
+	"sync"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+)
+
+type generatorStep struct {
+	value object.Object
+	done  bool
+}
+
+// generatorStopped is the Value of the error a parked yield returns once
+// its generator is stopped. The body unwinds with it through defer and
+// finally like with any error, but try cannot catch it.
+var generatorStopped = &object.String{Value: "generator stopped"}
+
+func isGeneratorStop(obj object.Object) bool {
+	errObj, ok := obj.(*object.Error)
+	return ok && errObj.Value == generatorStopped
+}
+
+// newGenerator prepares the call of a generator function. The body runs on
+// its own goroutine, started by the first Next and parked on every yield,
+// so only one side is ever running. Stop, called by the consumers that quit
+// early, makes the parked yield fail and waits until the body has unwound.
+// A generator the garbage collector finds unreachable is dropped without
+// running its deferred calls, as they would race with the program.
+func newGenerator(fn *object.Function, env *object.Environement) *object.Generator {
+	gen := &object.Generator{Function: fn}
+
+	steps := make(chan generatorStep)
+	resume := make(chan struct{})
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	var stop sync.Once
+	started, finished, abandoned := false, false, false
+
+	park := func() bool {
+		select {
+		case <-resume:
+			return true
+		case <-done:
+			if abandoned {
+				runtime.Goexit()
+			}
+			return false
+		}
+	}
+
+	// the body holds on to its own handle only, so it never keeps gen alive
+	body := &object.Generator{Function: fn}
+	body.Yield = func(val object.Object) object.Object {
+		select {
+		case steps <- generatorStep{value: val}:
+			if park() {
+				return nil
+			}
+		case <-done:
+			if abandoned {
+				runtime.Goexit()
+			}
+		}
+		return &object.Error{Message: "generator stopped", Value: generatorStopped}
+	}
+	gen.Yield = body.Yield
+
+	gen.Next = func() (object.Object, bool) {
+		if finished {
+			return NULL, true
+		}
+
+		if !started {
+			started = true
+			go func() {
+				defer close(exited)
+				result := Eval(fn.Body, env)
+				result = runDeferred(env, result)
+				select {
+				case steps <- generatorStep{value: unwrapReturnValue(result), done: true}:
+				case <-done:
+				}
+			}()
+		} else {
+			resume <- struct{}{}
+		}
+
+		step := <-steps
+		if step.done {
+			finished = true
+			if IsError(step.value) {
+				return step.value, true
+			}
+			return NULL, true
+		}
+
+		return step.value, false
+	}
+
+	gen.Stop = func() {
+		finished = true
+		stop.Do(func() {
+			close(done)
+			if started {
+				<-exited
+			}
+		})
+	}
+	runtime.SetFinalizer(gen, func(gen *object.Generator) {
+		abandoned = true
+		gen.Stop()
+	})
+
+	env.SetGenerator(body)
+	return gen
+}
+
+func evalYieldExpression(node *ast.YieldExpression, env *object.Environement) object.Object {
+	gen := env.Generator()
+	if gen == nil {
+		return NewError("yield outside generator")
+	}
+
+	val := Eval(node.Value, env)
+	if IsError(val) {
+		return val
+	}
+
+	if stopped := gen.Yield(val); stopped != nil {
+		return stopped
+	}
+	return NULL
+}
+
+// iterate calls fn for every element of iterable, with the index (or hash
+// key) and the element. A non-nil result of fn, such as an error or a
+// return value, stops the iteration and is handed back; a generator
+// stopped this way is not resumed again.
+func iterate(iterable object.Object, fn func(key, value object.Object) object.Object) object.Object {
+	switch iterable := iterable.(type) {
+	case *object.Array:
+		for i, element := range iterable.Elements {
+			if result := fn(&object.Integer{Value: int64(i)}, element); result != nil {
+				return result
+			}
+		}
+	case *object.Tuple:
+		for i, element := range iterable.Elements {
+			if result := fn(&object.Integer{Value: int64(i)}, element); result != nil {
+				return result
+			}
+		}
+	case *object.Hash:
+		for _, hashKey := range iterable.Keys {
+			pair := iterable.Pairs[hashKey]
+			if result := fn(pair.Key, pair.Value); result != nil {
+				return result
+			}
+		}
+	case *object.String:
+		for i := range iterable.Value {
+			char := &object.String{Value: iterable.Value[i : i+1]}
+			if result := fn(&object.Integer{Value: int64(i)}, char); result != nil {
+				return result
+			}
+		}
+	case *object.Range:
+		n, _ := iterable.Len()
+		for i := int64(0); i < n; i++ {
+			value, _ := iterable.At(i)
+			if result := fn(&object.Integer{Value: i}, &object.Integer{Value: value}); result != nil {
+				return result
+			}
+		}
+	case *object.Generator:
+		for i := int64(0); ; i++ {
+			value, done := iterable.Next()
+			if IsError(value) {
+				return value
+			}
+			if done {
+				break
+			}
+			if result := fn(&object.Integer{Value: i}, value); result != nil {
+				iterable.Stop()
+				return result
+			}
+		}
+	default:
+		return NewError("%s is not iterable", iterable.Type())
+	}
+
+	return nil
+}
+
+func evalForStatement(node *ast.ForStatement, env *object.Environement) object.Object {
+	iterable := Eval(node.Iterable, env)
+	if IsError(iterable) {
+		return iterable
+	}
+
+	return iterate(iterable, func(key, value object.Object) object.Object {
+		loopEnv := object.NewEnclosedEnvironment(env)
+		bindLoopVariables(node.Variables, iterable, key, value, loopEnv)
+
+		result := Eval(node.Body, loopEnv)
+		if result != nil && (result.Type() == object.ERROR_OBJ || result.Type() == object.RETURN_VALUE_OBJ) {
+			return result
+		}
+		return nil
+	})
+}
+
+// a single name gets the element, or the key when iterating a hash
+func bindLoopVariables(vars []*ast.Identifier, iterable, key, value object.Object, env *object.Environement) {
+	if len(vars) == 2 {
+		env.Set(vars[0].Value, key)
+		env.Set(vars[1].Value, value)
+		return
+	}
+
+	if iterable.Type() == object.HASH_OBJ {
+		env.Set(vars[0].Value, key)
+	} else {
+		env.Set(vars[0].Value, value)
+	}
+}
@@ -0,0 +1,148 @@
+package evaluator
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"com.language/monkey/object"
+)
+
+const counter = `
+let naturals = fn() {
+	let n = 0;
+	for x in [1, 1, 1, 1, 1, 1, 1, 1, 1, 1] {
+		yield n;
+		n = n + x;
+	}
+};
+`
+
+func TestGenerators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let g = fn() { yield 1; yield 2; }(); [next(g), next(g), next(g), next(g)]`, "[1, 2, null, null]"},
+		{`let g = fn() { yield 1; yield 2; }(); [g.next(), g.next()]`, "[1, 2]"},
+		{`let g = fn(x) { yield x; yield x * 2; return 100; }(5); [next(g), next(g), next(g)]`, "[5, 10, null]"},
+		{`take(naturals(), 3)`, "[0, 1, 2]"},
+		{`take(naturals(), 0)`, "[]"},
+		{`take([1, 2, 3], 2)`, "[1, 2]"},
+		{`let g = naturals(); next(g); take(g, 2)`, "[1, 2]"},
+		{`let out = []; for x in fn() { yield "a"; yield "b"; }() { out = push(out, x); }; out`, "[a, b]"},
+		{`let out = []; for i, x in fn() { yield "a"; yield "b"; }() { out = push(out, i); }; out`, "[0, 1]"},
+		{`let log = []; let g = fn() { log = push(log, "start"); yield 1; log = push(log, "resumed"); }(); log = push(log, "created"); next(g); log = push(log, "got"); next(g); log`, "[created, start, got, resumed]"},
+		{`let log = []; let g = fn() { defer log = push(log, "done"); yield 1; }(); next(g); next(g); log`, "[done]"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(counter + itm.input)
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+
+	if g := testEval(`fn() { yield 1; }()`); g == nil || g.Inspect() != "<generator>" {
+		t.Errorf("expect <generator>, got %+v", g)
+	}
+}
+
+func TestAbandonedGenerators(t *testing.T) {
+	if g := testEval(counter + `let g = naturals(); take(g, 2); [next(g), next(g)]`); g == nil || g.Inspect() != "[null, null]" {
+		t.Errorf("expect a generator stopped by take to be done, got %+v", g)
+	}
+
+	inputs := []string{
+		`for i in 0..200 { take(naturals(), 1) }`,
+		`let first = fn(g) { for x in g { return x } }; for i in 0..200 { first(naturals()) }`,
+		`for i in 0..200 { next(naturals()) }`,
+	}
+
+	for _, input := range inputs {
+		before := runtime.NumGoroutine()
+		testEval(counter + input)
+
+		// the parked bodies exit asynchronously, the last ones after a collection
+		after := 0
+		for try := 0; try < 100; try++ {
+			runtime.GC()
+			if after = runtime.NumGoroutine(); after <= before {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if after > before {
+			t.Errorf("%s: %d goroutines left behind", input, after-before)
+		}
+	}
+}
+
+func TestStoppedGeneratorsUnwind(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`let log = []; take(fn() { defer log = push(log, "cleanup"); yield 1; yield 2 }(), 1); log`,
+			"[cleanup]",
+		},
+		{
+			`let log = []; let g = fn() { defer log = push(log, "cleanup"); yield 1; yield 2 }; let first = fn() { for x in g() { return x } }; [first(), log]`,
+			"[1, [cleanup]]",
+		},
+		{
+			`let log = []; let g = fn() { try { yield 1; yield 2 } catch (e) { log = push(log, "caught") } finally { log = push(log, "finally") } }; take(g(), 1); log`,
+			"[finally]",
+		},
+		{
+			`let log = []; let g = fn() { for i in 0..3 { try { yield i } catch (e) { log = push(log, i) } } }; [take(g(), 2), log]`,
+			"[[0, 1], []]",
+		},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestForStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`let sum = 0; for x in [1, 2, 3] { sum = sum + x; }; sum`, 6},
+		{`let sum = 0; for i, x in [10, 20, 30] { sum = sum + i; }; sum`, 3},
+		{`let sum = 0; for k, v in {"a": 1, "b": 2} { sum = sum + v; }; sum`, 3},
+		{`let n = 0; for k in {"a": 1, "b": 2} { n = n + len(k); }; n`, 2},
+		{`let s = ""; for c in "abc" { s = c + s; }; s`, "cba"},
+		{`let f = fn() { for x in [1, 2, 3] { if (x == 2) { return x * 10; } } }; f()`, 20},
+		{`for x in 5 { x }`, "INTEGER is not iterable"},
+		{`for x in [1] { x + true }`, "type mismatch: INTEGER + BOOLEAN"},
+		{`let g = fn() { yield 1; 1 + true; }(); for x in g { x }`, "type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		switch expected := itm.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			switch obj := evaluated.(type) {
+			case *object.String:
+				if obj.Value != expected {
+					t.Errorf("expect %q, got %q", expected, obj.Value)
+				}
+			case *object.Error:
+				if obj.Message != expected {
+					t.Errorf("expect error %q, got %q", expected, obj.Message)
+				}
+			default:
+				t.Errorf("%s: unexpected result %T (%+v)", itm.input, evaluated, evaluated)
+			}
+		}
+	}
+}
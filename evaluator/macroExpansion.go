@@ -0,0 +1,141 @@
+package evaluator
+
+import (
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+)
+
+// DefineMacros moves every top level `let name = macro(...) {...};` out of the
+// program and binds it in env, so ExpandMacros can find it later.
+func DefineMacros(program *ast.Program, env *object.Environement) {
+	definitions := []int{}
+
+	for i, statement := range program.Statements {
+		if isMacroDefinition(statement) {
+			addMacro(statement, env)
+			definitions = append(definitions, i)
+		}
+	}
+
+	for i := len(definitions) - 1; i >= 0; i-- {
+		definitionIndex := definitions[i]
+		program.Statements = append(
+			program.Statements[:definitionIndex],
+			program.Statements[definitionIndex+1:]...,
+		)
+	}
+}
+
+func isMacroDefinition(node ast.Statement) bool {
+	letStatement, ok := node.(*ast.LetStatement)
+	if !ok {
+		return false
+	}
+
+	_, ok = letStatement.Value.(*ast.MacroLiteral)
+	return ok
+}
+
+func addMacro(stmt ast.Statement, env *object.Environement) {
+	letStatement, _ := stmt.(*ast.LetStatement)
+	macroLiteral, _ := letStatement.Value.(*ast.MacroLiteral)
+
+	macro := &object.Macro{
+		Parameters: macroLiteral.Parameters,
+		Env:        env,
+		Body:       macroLiteral.Body,
+	}
+
+	env.Set(letStatement.Name.Value, macro)
+}
+
+// ExpandMacros replaces every call of a defined macro by the quoted AST the
+// macro returns. It stops at the first macro call that fails and returns
+// its error.
+func ExpandMacros(program ast.Node, env *object.Environement) (ast.Node, *object.Error) {
+	var failure *object.Error
+
+	expanded := ast.Modify(program, func(node ast.Node) ast.Node {
+		if failure != nil {
+			return node
+		}
+
+		callExpression, ok := node.(*ast.CallExpression)
+		if !ok {
+			return node
+		}
+
+		macro, ok := isMacroCall(callExpression, env)
+		if !ok {
+			return node
+		}
+
+		name := callExpression.Function.String()
+		if len(callExpression.Arguments) != len(macro.Parameters) {
+			failure = NewError("wrong number of arguments to macro %s. got %d, want %d",
+				name, len(callExpression.Arguments), len(macro.Parameters))
+			return node
+		}
+
+		args := quoteArgs(callExpression)
+		evalEnv := extendMacroEnv(macro, args)
+
+		evaluated := unwrapReturnValue(Eval(macro.Body, evalEnv))
+		if evaluated == nil {
+			evaluated = NULL
+		}
+		if err, ok := evaluated.(*object.Error); ok {
+			failure = err
+			return node
+		}
+
+		expanded := convertObjectToASTNode(evaluated)
+		if expanded == nil {
+			failure = NewError("macro %s must return code, got %s", name, evaluated.Type())
+			return node
+		}
+
+		return expanded
+	})
+
+	return expanded, failure
+}
+
+func isMacroCall(exp *ast.CallExpression, env *object.Environement) (*object.Macro, bool) {
+	identifier, ok := exp.Function.(*ast.Identifier)
+	if !ok {
+		return nil, false
+	}
+
+	obj, ok := env.Get(identifier.Value)
+	if !ok {
+		return nil, false
+	}
+
+	macro, ok := obj.(*object.Macro)
+	if !ok {
+		return nil, false
+	}
+
+	return macro, true
+}
+
+func quoteArgs(exp *ast.CallExpression) []*object.Quote {
+	args := []*object.Quote{}
+
+	for _, a := range exp.Arguments {
+		args = append(args, &object.Quote{Node: a})
+	}
+
+	return args
+}
+
+func extendMacroEnv(macro *object.Macro, args []*object.Quote) *object.Environement {
+	extended := object.NewEnclosedEnvironment(macro.Env)
+
+	for paramIdx, param := range macro.Parameters {
+		extended.Set(param.Value, args[paramIdx])
+	}
+
+	return extended
+}
@@ -0,0 +1,238 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/lexer"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+)
+
+func TestDefineMacros(t *testing.T) {
+	input := `
+	let number = 1;
+	let function = fn(x, y) { x + y };
+	let mymacro = macro(x, y) { x + y; };
+	`
+
+	env := object.NewEnvironment()
+	program := testParseProgram(input)
+
+	DefineMacros(program, env)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("wrong number of statements. got %d", len(program.Statements))
+	}
+
+	if _, ok := env.Get("number"); ok {
+		t.Fatalf("number should not be defined")
+	}
+
+	if _, ok := env.Get("function"); ok {
+		t.Fatalf("function should not be defined")
+	}
+
+	obj, ok := env.Get("mymacro")
+	if !ok {
+		t.Fatalf("macro not in environment")
+	}
+
+	macro, ok := obj.(*object.Macro)
+	if !ok {
+		t.Fatalf("object is not Macro. got %T (%+v)", obj, obj)
+	}
+
+	if len(macro.Parameters) != 2 {
+		t.Fatalf("wrong number of macro parameters. got %d", len(macro.Parameters))
+	}
+
+	if macro.Parameters[0].String() != "x" {
+		t.Fatalf("parameter is not 'x'. got %q", macro.Parameters[0])
+	}
+
+	if macro.Parameters[1].String() != "y" {
+		t.Fatalf("parameter is not 'y'. got %q", macro.Parameters[1])
+	}
+
+	expectedBody := "(x + y)"
+
+	if macro.Body.String() != expectedBody {
+		t.Fatalf("body is not %q. got %q", expectedBody, macro.Body.String())
+	}
+}
+
+func TestExpandMacros(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`
+			let infixExpression = macro() { quote(1 + 2); };
+
+			infixExpression();
+			`,
+			`(1 + 2)`,
+		},
+		{
+			`
+			let reverse = macro(a, b) { quote(unquote(b) - unquote(a)); };
+
+			reverse(2 + 2, 10 - 5);
+			`,
+			`(10 - 5) - (2 + 2)`,
+		},
+		{
+			`
+			let unless = macro(condition, consequence, alternative) {
+				quote(if (!(unquote(condition))) {
+					unquote(consequence);
+				} else {
+					unquote(alternative);
+				});
+			};
+
+			unless(10 > 5, puts("not greater"), puts("greater"));
+			`,
+			`if (!(10 > 5)) { puts("not greater") } else { puts("greater") }`,
+		},
+	}
+
+	for _, itm := range tests {
+		expected := testParseProgram(itm.expected)
+		program := testParseProgram(itm.input)
+
+		env := object.NewEnvironment()
+		DefineMacros(program, env)
+		expanded, err := ExpandMacros(program, env)
+		if err != nil {
+			t.Fatalf("unexpected error %s", err.Inspect())
+		}
+
+		if expanded.String() != expected.String() {
+			t.Errorf("not equal. want %q, got %q", expected.String(), expanded.String())
+		}
+	}
+}
+
+func TestMacroUnless(t *testing.T) {
+	input := `
+	let unless = macro(condition, consequence, alternative) {
+		quote(if (!(unquote(condition))) {
+			unquote(consequence);
+		} else {
+			unquote(alternative);
+		});
+	};
+
+	unless(10 > 5, 1, 2);
+	`
+
+	program := testParseProgram(input)
+	env := object.NewEnvironment()
+	DefineMacros(program, env)
+	expanded, err := ExpandMacros(program, env)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err.Inspect())
+	}
+
+	testIntegerObject(t, Eval(expanded, object.NewEnvironment()), 2)
+}
+
+func TestMacroCalledTwice(t *testing.T) {
+	input := `
+	let double = macro(a) { quote(unquote(a) * 2) };
+	let unless = macro(condition, consequence, alternative) {
+		quote(if (!(unquote(condition))) { unquote(consequence) } else { unquote(alternative) });
+	};
+
+	let a = double(1);
+	let b = double(5);
+	let c = unless(1 > 2, "first-then", "first-else");
+	let d = unless(3 > 2, "second-then", "second-else");
+	[a, b, c, d]
+	`
+
+	program := testParseProgram(input)
+	env := object.NewEnvironment()
+	DefineMacros(program, env)
+	expanded, err := ExpandMacros(program, env)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err.Inspect())
+	}
+
+	evaluated := Eval(expanded, object.NewEnvironment())
+	expected := `[2, 10, first-then, second-else]`
+	if evaluated.Inspect() != expected {
+		t.Errorf("expect %s, got %s", expected, evaluated.Inspect())
+	}
+}
+
+func TestExpandMacroErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`let m = macro(a, b) { quote(unquote(a)) }; m(1)`,
+			"wrong number of arguments to macro m. got 1, want 2",
+		},
+		{
+			`let m = macro(a) { quote(unquote(a)) }; m(1, 2)`,
+			"wrong number of arguments to macro m. got 2, want 1",
+		},
+		{
+			`let m = macro(a) { quote(unquote(fn(x) { x })) }; m(1)`,
+			"cannot unquote FUNCTION",
+		},
+		{
+			`let m = macro() { missing }; m()`,
+			"identifier not fond: missing",
+		},
+		{
+			`let m = macro() { }; m()`,
+			"macro m must return code, got NULL",
+		},
+	}
+
+	for _, itm := range tests {
+		program := testParseProgram(itm.input)
+		env := object.NewEnvironment()
+		DefineMacros(program, env)
+
+		_, err := ExpandMacros(program, env)
+		if err == nil {
+			t.Errorf("%s: expect error %q", itm.input, itm.expected)
+			continue
+		}
+		if err.Message != itm.expected {
+			t.Errorf("%s: expect error %q, got %q", itm.input, itm.expected, err.Message)
+		}
+	}
+}
+
+func TestUnboundMacroLiterals(t *testing.T) {
+	tests := []string{
+		`let f = fn() { let m = macro(x) { x }; m(1) }; f();`,
+		`puts(macro(x) { x })`,
+		`len(macro(x) { x })`,
+	}
+
+	for _, input := range tests {
+		err, ok := testEval(input).(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect an error", input)
+			continue
+		}
+		if err.Message != "macro literals must be bound by a top-level let" {
+			t.Errorf("%s: wrong error %q", input, err.Message)
+		}
+	}
+}
+
+func testParseProgram(input string) *ast.Program {
+	l := lexer.New(input)
+	p := parser.New(l)
+	return p.ParserProgram()
+}
@@ -0,0 +1,199 @@
+package evaluator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/lexer"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+	"com.language/monkey/token"
+)
+
+var (
+	// modules are evaluated once and cached by absolute path
+	moduleCache = map[string]*object.Module{}
+	// absolute paths of the modules currently being evaluated, innermost last
+	importStack = []string{}
+)
+
+// ImportModule loads the file at path (relative to the working directory) as a module.
+func ImportModule(path string) object.Object {
+	return loadModule(path)
+}
+
+// RunFile evaluates the script at path as the main module. Unlike an
+// import, errors in the script itself are returned as they are.
+func RunFile(path string) object.Object {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return NewError("cannot run %q: %s", path, err)
+	}
+
+	source, err := os.ReadFile(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return NewError("cannot run %q: no such file", displayPath(abs))
+		}
+		return NewError("cannot run %q: %s", displayPath(abs), err)
+	}
+
+	p := parser.New(lexer.New(string(source)))
+	program := p.ParserProgram()
+	if len(p.Errors()) > 0 {
+		return NewError("%s: %s", displayPath(abs), strings.Join(p.Errors(), "; "))
+	}
+
+	return evalModule(abs, program)
+}
+
+func evalImportStatement(node *ast.ImportStatement, env *object.Environement) object.Object {
+	path := node.Path.Value
+	if !filepath.IsAbs(path) {
+		base := "."
+		if module := env.Module(); module != nil {
+			base = filepath.Dir(module.Path)
+		}
+		path = filepath.Join(base, path)
+	}
+
+	name := moduleName(path)
+	if node.Alias != nil {
+		name = node.Alias.Value
+	} else if !isIdentifier(name) {
+		return NewError("cannot derive a module name from %q, use `import %q as <name>`", node.Path.Value, node.Path.Value)
+	}
+
+	module := loadModule(path)
+	if IsError(module) {
+		return module
+	}
+
+	env.Set(name, module)
+	return nil
+}
+
+func evalExportStatement(node *ast.ExportStatement, env *object.Environement) object.Object {
+	module := env.Module()
+	if module == nil || module.Env != env {
+		return NewError("export is only allowed at the top level of a module")
+	}
+
+	val := Eval(node.Statement, env)
+	if IsError(val) {
+		return val
+	}
+
+	name := node.Statement.Name.Value
+	module.Exports[name], _ = env.Get(name)
+
+	return nil
+}
+
+func evalModuleMember(module *object.Module, name string) object.Object {
+	if val, ok := module.Exports[name]; ok {
+		return val
+	}
+
+	return NewError("module %s has no export named %s", module.Name, name)
+}
+
+func loadModule(path string) object.Object {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return NewError("cannot import %q: %s", path, err)
+	}
+
+	for i, loading := range importStack {
+		if loading == abs {
+			cycle := []string{}
+			for _, itm := range importStack[i:] {
+				cycle = append(cycle, displayPath(itm))
+			}
+			cycle = append(cycle, displayPath(abs))
+			return NewError("import cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	if module, ok := moduleCache[abs]; ok {
+		return module
+	}
+
+	source, err := os.ReadFile(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return NewError("cannot import %q: no such file", displayPath(abs))
+		}
+		return NewError("cannot import %q: %s", displayPath(abs), err)
+	}
+
+	p := parser.New(lexer.New(string(source)))
+	program := p.ParserProgram()
+	if len(p.Errors()) > 0 {
+		return NewError("cannot import %q: %s", displayPath(abs), strings.Join(p.Errors(), "; "))
+	}
+
+	return evalModule(abs, program)
+}
+
+// evalModule expands and evaluates the parsed file at abs and caches the
+// resulting module.
+func evalModule(abs string, program *ast.Program) object.Object {
+	macroEnv := object.NewEnvironment()
+	DefineMacros(program, macroEnv)
+	expanded, failure := ExpandMacros(program, macroEnv)
+	if failure != nil {
+		return failure
+	}
+
+	module := &object.Module{
+		Name:    moduleName(abs),
+		Path:    abs,
+		Exports: make(map[string]object.Object),
+	}
+	env := object.NewModuleEnvironment(module)
+
+	importStack = append(importStack, abs)
+	result := Eval(expanded, env)
+	importStack = importStack[:len(importStack)-1]
+
+	if IsError(result) {
+		return result
+	}
+
+	moduleCache[abs] = module
+	return module
+}
+
+// lib/strings.mk -> strings
+func moduleName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+func isIdentifier(name string) bool {
+	if name == "" || token.LoopupIdentifier(name) != token.IDENT {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !lexer.IsLitter(name[i]) && (i == 0 || !lexer.IsDigital(name[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// paths below the working directory are shown relative to it
+func displayPath(abs string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return abs
+	}
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return abs
+	}
+	return rel
+}
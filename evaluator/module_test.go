@@ -0,0 +1,157 @@
+package evaluator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func writeModules(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %s", path, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %s", path, err)
+		}
+	}
+
+	return dir
+}
+
+func TestImportModule(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"main.mk": `
+			import "lib/math.mk";
+			import "lib/math.mk" as m;
+			let result = math.add(m.two, 3);
+			export let answer = result;
+		`,
+		"lib/math.mk": `
+			import "consts.mk";
+			export let add = fn(x, y) { x + y };
+			export let two = consts.two;
+			let hidden = 1;
+		`,
+		"lib/consts.mk": `export let two = 2;`,
+	})
+
+	result := ImportModule(filepath.Join(dir, "main.mk"))
+	module, ok := result.(*object.Module)
+	if !ok {
+		t.Fatalf("expect *object.Module, got %T (%+v)", result, result)
+	}
+
+	testIntegerObject(t, module.Exports["answer"], 5)
+
+	lib := ImportModule(filepath.Join(dir, "lib", "math.mk"))
+	if _, ok := lib.(*object.Module).Exports["hidden"]; ok {
+		t.Errorf("unexported binding hidden leaked into exports")
+	}
+}
+
+func TestImportModuleEvaluatedOnce(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"main.mk": `
+			import "counter.mk" as a;
+			import "counter.mk" as b;
+			export let same = a == b;
+		`,
+		"counter.mk": `export let n = 1;`,
+	})
+
+	result := ImportModule(filepath.Join(dir, "main.mk"))
+	module, ok := result.(*object.Module)
+	if !ok {
+		t.Fatalf("expect *object.Module, got %T (%+v)", result, result)
+	}
+
+	testBoolObject(t, module.Exports["same"], true)
+}
+
+func TestImportModuleErrors(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"cycle_a.mk":   `import "cycle_b.mk";`,
+		"cycle_b.mk":   `import "cycle_a.mk";`,
+		"missing.mk":   `import "nothing.mk";`,
+		"private.mk":   `import "lib.mk"; lib.hidden;`,
+		"lib.mk":       `let hidden = 1; export let shown = 2;`,
+		"bad-name.mk":  `export let x = 1;`,
+		"badname.mk":   `import "bad-name.mk";`,
+		"nested.mk":    `let f = fn() { export let x = 1; }; f();`,
+		"brokenuse.mk": `import "broken.mk";`,
+		"broken.mk":    `let = 1;`,
+	})
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{"cycle_a.mk", "import cycle: "},
+		{"cycle_a.mk", "cycle_a.mk -> "},
+		{"missing.mk", "nothing.mk\": no such file"},
+		{"private.mk", "module lib has no export named hidden"},
+		{"badname.mk", "cannot derive a module name from \"bad-name.mk\""},
+		{"nested.mk", "export is only allowed at the top level of a module"},
+		{"brokenuse.mk", "expect next token to be IDENT, got = instead"},
+	}
+
+	for _, itm := range tests {
+		result := ImportModule(filepath.Join(dir, itm.file))
+		errObj, ok := result.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.file, result, result)
+			continue
+		}
+
+		if !strings.Contains(errObj.Message, itm.expected) {
+			t.Errorf("%s: expect error containing %q, got %q", itm.file, itm.expected, errObj.Message)
+		}
+	}
+}
+
+func TestRunFile(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"main.mk":    `import "lib.mk"; export let x = lib.two + 1;`,
+		"lib.mk":     `export let two = 2;`,
+		"failing.mk": `let x = 1; x + true;`,
+		"broken.mk":  `let = 1;`,
+		"uses.mk":    `import "failing.mk";`,
+	})
+
+	result := RunFile(filepath.Join(dir, "main.mk"))
+	module, ok := result.(*object.Module)
+	if !ok {
+		t.Fatalf("expect *object.Module, got %T (%+v)", result, result)
+	}
+	testIntegerObject(t, module.Exports["x"], 3)
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{"failing.mk", "type mismatch: INTEGER + BOOLEAN"},
+		{"broken.mk", "broken.mk: expect next token to be IDENT, got = instead"},
+		{"nothing.mk", "nothing.mk\": no such file"},
+		{"uses.mk", "type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, itm := range tests {
+		result := RunFile(filepath.Join(dir, itm.file))
+		errObj, ok := result.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.file, result, result)
+			continue
+		}
+
+		if !strings.Contains(errObj.Message, itm.expected) || strings.Contains(errObj.Message, "cannot import") {
+			t.Errorf("%s: expect error %q, got %q", itm.file, itm.expected, errObj.Message)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+
+	"com.language/monkey/object"
+)
+
+// Hashes can overload operators by storing functions under special keys.
+// Every method takes the receiver first: {"__add__": fn(self, other) {...}}
+// Class instances define them as ordinary methods instead.
+var operatorMethods = map[string]string{
+	"+":  "__add__",
+	"-":  "__sub__",
+	"*":  "__mul__",
+	"/":  "__div__",
+	"==": "__eq__",
+	"<":  "__lt__",
+	">":  "__gt__",
+}
+
+// the method tried on the right operand when the left one has none
+var reflectedMethods = map[string]string{
+	"==": "__eq__",
+	"<":  "__gt__",
+	">":  "__lt__",
+}
+
+// callOperatorMethod calls the special method name of obj, reporting
+// false when obj does not define it.
+func callOperatorMethod(obj object.Object, name string, args ...object.Object) (object.Object, bool) {
+	switch obj := obj.(type) {
+	case *object.Hash:
+		pair, ok := obj.Pairs[(&object.String{Value: name}).HashKey()]
+		if !ok {
+			return nil, false
+		}
+
+		switch pair.Value.(type) {
+		case *object.Function, *object.Builtin:
+			return applyFunction(pair.Value, append([]object.Object{obj}, args...)), true
+		}
+	case *object.Instance:
+		method, owner := obj.Class.Lookup(name)
+		if method != nil {
+			return callMethod(&object.BoundMethod{Receiver: obj, Method: method, Owner: owner}, args), true
+		}
+	}
+	return nil, false
+}
+
+func evalOverloadedInfixExpression(operator string, left, right object.Object) (object.Object, bool) {
+	if operator == "!=" {
+		result, ok := evalOverloadedInfixExpression("==", left, right)
+		if !ok || IsError(result) {
+			return result, ok
+		}
+		return nativeBooltoToBooleanObject(!isTruthy(result)), true
+	}
+
+	if name, ok := operatorMethods[operator]; ok {
+		if result, ok := callOperatorMethod(left, name, right); ok {
+			return result, true
+		}
+	}
+
+	if name, ok := reflectedMethods[operator]; ok {
+		if result, ok := callOperatorMethod(right, name, left); ok {
+			return result, true
+		}
+	}
+
+	return nil, false
+}
+
+// __index__ is only consulted for keys the hash does not contain, so the
+// method itself can still read the hash's own fields.
+func evalOverloadedIndexExpression(hash *object.Hash, index object.Object) (object.Object, bool) {
+	if key, ok := object.Hashable(index); ok {
+		if _, ok := hash.Pairs[key.HashKey()]; ok {
+			return nil, false
+		}
+	}
+
+	return callOperatorMethod(hash, "__index__", index)
+}
+
+// Inspect renders obj for display, using __str__ where a value defines it.
+func Inspect(obj object.Object) string {
+	if result, ok := callOperatorMethod(obj, "__str__"); ok {
+		if str, ok := result.(*object.String); ok {
+			return str.Value
+		}
+		if IsError(result) {
+			return result.Inspect()
+		}
+		if result == nil {
+			result = NULL
+		}
+		return NewError("__str__ must return a string, got %s", result.Type()).Inspect()
+	}
+
+	switch obj := obj.(type) {
+	case *object.Array:
+		elements := []string{}
+		for _, element := range obj.Elements {
+			elements = append(elements, Inspect(element))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case *object.Tuple:
+		elements := []string{}
+		for _, element := range obj.Elements {
+			elements = append(elements, Inspect(element))
+		}
+		if len(elements) == 1 {
+			return "(" + elements[0] + ",)"
+		}
+		return "(" + strings.Join(elements, ", ") + ")"
+	case *object.Hash:
+		pairs := []string{}
+		for _, key := range obj.Keys {
+			pair := obj.Pairs[key]
+			pairs = append(pairs, fmt.Sprintf("%s:%s", Inspect(pair.Key), Inspect(pair.Value)))
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	case *object.Instance:
+		fields := []string{}
+		for _, name := range obj.Names {
+			fields = append(fields, name+": "+Inspect(obj.Fields[name]))
+		}
+		return obj.Class.Name + "{" + strings.Join(fields, ", ") + "}"
+	}
+
+	return obj.Inspect()
+}
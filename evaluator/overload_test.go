@@ -0,0 +1,96 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+const vector = `
+let vec = fn(x, y) {
+	{
+		"x": x,
+		"y": y,
+		"__add__": fn(self, other) { vec(self["x"] + other["x"], self["y"] + other["y"]) },
+		"__sub__": fn(self, other) { vec(self["x"] - other["x"], self["y"] - other["y"]) },
+		"__eq__": fn(self, other) { if (self["x"] == other["x"]) { self["y"] == other["y"] } else { false } },
+		"__lt__": fn(self, other) { self["x"] * self["x"] + self["y"] * self["y"] < other["x"] * other["x"] + other["y"] * other["y"] },
+		"__index__": fn(self, i) { if (i == 0) { self["x"] } else { self["y"] } },
+	}
+};
+
+let named = fn(name) { {"name": name, "__str__": fn(self) { "<" + self["name"] + ">" }} };
+`
+
+func TestOperatorOverloading(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let v = vec(1, 2) + vec(3, 4); [v["x"], v["y"]]`, "[4, 6]"},
+		{`let v = vec(5, 5) - vec(1, 2); [v[0], v[1]]`, "[4, 3]"},
+		{`vec(1, 2) == vec(1, 2)`, "true"},
+		{`vec(1, 2) == vec(2, 1)`, "false"},
+		{`vec(1, 2) != vec(2, 1)`, "true"},
+		{`vec(1, 1) < vec(2, 2)`, "true"},
+		{`vec(3, 3) > vec(2, 2)`, "true"},
+		{`vec(1, 2)["x"]`, "1"},
+		{`vec(7, 8)[1]`, "8"},
+		{`{"a": 1} == {"a": 1}`, "false"},
+		{`let h = {"a": 1}; h == h`, "true"},
+		{`{"a": 1}["b"]`, "null"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(vector + itm.input)
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestOverloadedInspect(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`named("a")`, "<a>"},
+		{`[named("a"), named("b")]`, "[<a>, <b>]"},
+		{`{"origin": named("o")}`, "{origin:<o>}"},
+		{`{"__str__": fn(self) { 42 }}`, "ERROR: __str__ must return a string, got INTEGER"},
+		{`{"__str__": fn(self) { self }}`, "ERROR: __str__ must return a string, got HASH"},
+		{`[{"__str__": fn(self) { }}]`, "[ERROR: __str__ must return a string, got NULL]"},
+		{`{"a": [1, 2]}`, "{a:[1, 2]}"},
+		{`"plain"`, "plain"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(vector + itm.input)
+		if got := Inspect(evaluated); got != itm.expected {
+			t.Errorf("%s: expect %s, got %s", itm.input, itm.expected, got)
+		}
+	}
+}
+
+func TestOverloadErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`vec(1, 2) * vec(1, 2)`, "unknow operator: HASH * HASH"},
+		{`1 + vec(1, 2)`, "type mismatch: INTEGER + HASH"},
+		{`{"__add__": 1} + {"__add__": 1}`, "unknow operator: HASH + HASH"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(vector + itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != itm.expected {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expected, errObj.Message)
+		}
+	}
+}
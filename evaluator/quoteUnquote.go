@@ -0,0 +1,138 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+	"com.language/monkey/token"
+)
+
+func quote(node ast.Node, env *object.Environement) object.Object {
+	// the quoted node belongs to the function or macro body, every call needs its own
+	node, err := evalUnquoteCalls(ast.Copy(node), env)
+	if err != nil {
+		return err
+	}
+	return &object.Quote{Node: node}
+}
+
+// replace every unquote(...) inside the quoted node by the AST form of its evaluated argument
+func evalUnquoteCalls(quoted ast.Node, env *object.Environement) (ast.Node, *object.Error) {
+	var failure *object.Error
+
+	node := ast.Modify(quoted, func(node ast.Node) ast.Node {
+		if failure != nil || !isUnquoteCall(node) {
+			return node
+		}
+
+		call, ok := node.(*ast.CallExpression)
+		if !ok {
+			return node
+		}
+
+		if len(call.Arguments) != 1 {
+			failure = NewError("wrong number of arguments to unquote. got %d, want 1", len(call.Arguments))
+			return node
+		}
+
+		unquoted := Eval(call.Arguments[0], env)
+		if unquoted == nil {
+			unquoted = NULL
+		}
+		if err, ok := unquoted.(*object.Error); ok {
+			failure = err
+			return node
+		}
+
+		converted := convertObjectToASTNode(unquoted)
+		if converted == nil {
+			failure = NewError("cannot unquote %s", unquoted.Type())
+			return node
+		}
+		return converted
+	})
+
+	return node, failure
+}
+
+func isUnquoteCall(node ast.Node) bool {
+	callExpression, ok := node.(*ast.CallExpression)
+	if !ok {
+		return false
+	}
+
+	return callExpression.Function.TokenLiteral() == "unquote"
+}
+
+func convertObjectToASTNode(obj object.Object) ast.Node {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		t := token.Token{
+			Type:    token.INT,
+			Literal: fmt.Sprintf("%d", obj.Value),
+		}
+		return &ast.IntegerLiteral{Token: t, Value: obj.Value}
+
+	case *object.Boolean:
+		var t token.Token
+		if obj.Value {
+			t = token.Token{Type: token.TRUE, Literal: "true"}
+		} else {
+			t = token.Token{Type: token.FALSE, Literal: "false"}
+		}
+		return &ast.Boolean{Token: t, Value: obj.Value}
+
+	case *object.String:
+		t := token.Token{Type: token.STRING, Literal: obj.Value}
+		return &ast.StringLiteral{Token: t, Value: obj.Value}
+
+	case *object.Quote:
+		return obj.Node
+
+	case *object.Array:
+		elements, ok := convertObjectsToASTNodes(obj.Elements)
+		if !ok {
+			return nil
+		}
+		t := token.Token{Type: token.LBRACKET, Literal: "["}
+		return &ast.ArrayLiteral{Token: t, Elements: elements}
+
+	case *object.Tuple:
+		elements, ok := convertObjectsToASTNodes(obj.Elements)
+		if !ok {
+			return nil
+		}
+		t := token.Token{Type: token.LPAREN, Literal: "("}
+		return &ast.TupleLiteral{Token: t, Elements: elements}
+
+	case *object.Hash:
+		pairs := []*ast.HashLiteralPair{}
+		for _, key := range obj.Keys {
+			pair := obj.Pairs[key]
+			k, okKey := convertObjectToASTNode(pair.Key).(ast.Expression)
+			v, okValue := convertObjectToASTNode(pair.Value).(ast.Expression)
+			if !okKey || !okValue {
+				return nil
+			}
+			pairs = append(pairs, &ast.HashLiteralPair{Key: k, Value: v})
+		}
+		t := token.Token{Type: token.LBRACE, Literal: "{"}
+		return &ast.HashLiteral{Token: t, Pairs: pairs}
+
+	default:
+		return nil
+	}
+}
+
+func convertObjectsToASTNodes(objs []object.Object) ([]ast.Expression, bool) {
+	exps := []ast.Expression{}
+	for _, obj := range objs {
+		exp, ok := convertObjectToASTNode(obj).(ast.Expression)
+		if !ok {
+			return nil, false
+		}
+		exps = append(exps, exp)
+	}
+	return exps, true
+}
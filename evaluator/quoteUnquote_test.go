@@ -0,0 +1,132 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`quote(5)`, `5`},
+		{`quote(5 + 8)`, `(5 + 8)`},
+		{`quote(foobar)`, `foobar`},
+		{`quote(foobar + barfoo)`, `(foobar + barfoo)`},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		quote, ok := evaluated.(*object.Quote)
+		if !ok {
+			t.Fatalf("expect *object.Quote. got %T (%+v)", evaluated, evaluated)
+		}
+
+		if quote.Node == nil {
+			t.Fatalf("quote.Node is nil")
+		}
+
+		if quote.Node.String() != itm.expected {
+			t.Errorf("not equal. got %q, want %q", quote.Node.String(), itm.expected)
+		}
+	}
+}
+
+func TestQuoteUnquote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`quote(unquote(4))`, `4`},
+		{`quote(unquote(4 + 4))`, `8`},
+		{`quote(8 + unquote(4 + 4))`, `(8 + 8)`},
+		{`quote(unquote(4 + 4) + 8)`, `(8 + 8)`},
+		{`let foobar = 8; quote(foobar)`, `foobar`},
+		{`let foobar = 8; quote(unquote(foobar))`, `8`},
+		{`quote(unquote(true))`, `true`},
+		{`quote(unquote(true == false))`, `false`},
+		{`quote(unquote(quote(4 + 4)))`, `(4 + 4)`},
+		{
+			`let quotedInfixExpression = quote(4 + 4);
+			quote(unquote(4 + 4) + unquote(quotedInfixExpression))`,
+			`(8 + (4 + 4))`,
+		},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		quote, ok := evaluated.(*object.Quote)
+		if !ok {
+			t.Fatalf("expect *object.Quote. got %T (%+v)", evaluated, evaluated)
+		}
+
+		if quote.Node == nil {
+			t.Fatalf("quote.Node is nil")
+		}
+
+		if quote.Node.String() != itm.expected {
+			t.Errorf("not equal. got %q, want %q", quote.Node.String(), itm.expected)
+		}
+	}
+}
+
+func TestQuoteInFunctionCalledTwice(t *testing.T) {
+	evaluated := testEval(`let f = fn(x) { quote(unquote(x) * 2) }; [f(1), f(5)]`)
+
+	expected := `[QUOTE((1 * 2)), QUOTE((5 * 2))]`
+	if evaluated.Inspect() != expected {
+		t.Errorf("expect %s, got %s", expected, evaluated.Inspect())
+	}
+}
+
+func TestUnquoteCollections(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`quote(unquote([1, 2]))`, `[1, 2]`},
+		{`quote(unquote([1, 1 + 1]) + 1)`, `([1, 2] + 1)`},
+		{`quote(unquote({"a": [true]}))`, `{a:[true]}`},
+		{`quote(unquote((1, "b")))`, `(1, b)`},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		quote, ok := evaluated.(*object.Quote)
+		if !ok {
+			t.Fatalf("%s: expect *object.Quote. got %T (%+v)", itm.input, evaluated, evaluated)
+		}
+
+		if quote.Node.String() != itm.expected {
+			t.Errorf("not equal. got %q, want %q", quote.Node.String(), itm.expected)
+		}
+	}
+}
+
+func TestQuoteErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`quote()`, "wrong number of arguments to quote. got 0, want 1"},
+		{`quote(1, 2)`, "wrong number of arguments to quote. got 2, want 1"},
+		{`quote(unquote())`, "wrong number of arguments to unquote. got 0, want 1"},
+		{`quote(unquote(fn(x) { x }) + 1)`, "cannot unquote FUNCTION"},
+		{`quote(unquote([1, fn() { 2 }]))`, "cannot unquote ARRAY"},
+		{`quote(unquote(missing))`, "identifier not fond: missing"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		err, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.input, evaluated, evaluated)
+			continue
+		}
+		if err.Message != itm.expected {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expected, err.Message)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package evaluator
+
+import (
+	"strings"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+)
+
+func evalRangeExpression(node *ast.RangeExpression, env *object.Environement) object.Object {
+	bounds := []ast.Expression{node.Start, node.End}
+	if node.Step != nil {
+		bounds = append(bounds, node.Step)
+	}
+
+	args := evalExpressions(bounds, env)
+	if len(args) == 1 && IsError(args[0]) {
+		return args[0]
+	}
+
+	return newRange(args...)
+}
+
+// newRange accepts (end), (start, end) or (start, end, step).
+func newRange(args ...object.Object) object.Object {
+	if len(args) < 1 || len(args) > 3 {
+		return NewError("wrong number of arguments. got %d, want 1 to 3", len(args))
+	}
+
+	values := []int64{}
+	for _, arg := range args {
+		integer, ok := arg.(*object.Integer)
+		if !ok {
+			return NewError("range bounds must be INTEGER, got %s", arg.Type())
+		}
+		values = append(values, integer.Value)
+	}
+
+	r := &object.Range{Step: 1}
+	switch len(values) {
+	case 1:
+		r.End = values[0]
+	case 2:
+		r.Start, r.End = values[0], values[1]
+	case 3:
+		r.Start, r.End, r.Step = values[0], values[1], values[2]
+	}
+
+	if r.Step == 0 {
+		return NewError("range step must not be 0")
+	}
+
+	return r
+}
+
+func evalRangeIndexExpression(r *object.Range, index object.Object) object.Object {
+	i, ok := index.(*object.Integer)
+	if !ok {
+		return NewError("range index must be INTEGER, got %s", index.Type())
+	}
+
+	val, ok := r.At(i.Value)
+	if !ok {
+		return NULL
+	}
+
+	return &object.Integer{Value: val}
+}
+
+// left in right
+func evalInExpression(left, right object.Object) object.Object {
+	switch right := right.(type) {
+	case *object.Range:
+		integer, ok := left.(*object.Integer)
+		return nativeBooltoToBooleanObject(ok && right.Contains(integer.Value))
+	case *object.Array:
+		for _, element := range right.Elements {
+			if objectsEqual(left, element) {
+				return TRUE
+			}
+		}
+		return FALSE
+	case *object.Tuple:
+		for _, element := range right.Elements {
+			if objectsEqual(left, element) {
+				return TRUE
+			}
+		}
+		return FALSE
+	case *object.Hash:
+		key, ok := object.Hashable(left)
+		if !ok {
+			return NewError("unusable as hash key: %s", left.Type())
+		}
+		_, ok = right.Pairs[key.HashKey()]
+		return nativeBooltoToBooleanObject(ok)
+	case *object.String:
+		str, ok := left.(*object.String)
+		if !ok {
+			return NewError("type mismatch: %s in %s", left.Type(), right.Type())
+		}
+		return nativeBooltoToBooleanObject(strings.Contains(right.Value, str.Value))
+	default:
+		return NewError("unknow operator: %s in %s", left.Type(), right.Type())
+	}
+}
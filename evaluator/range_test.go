@@ -0,0 +1,91 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func TestRanges(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"1..5", "1..5"},
+		{"0..10 step 3", "0..10 step 3"},
+		{"range(3)", "0..3"},
+		{"range(2, 8, 2)", "2..8 step 2"},
+		{"len(0..10)", 10},
+		{"len(0..10 step 3)", 4},
+		{"len(10..0 step -3)", 4},
+		{"len(5..1)", 0},
+		{"(0..10 step 3)[2]", 6},
+		{"(10..0 step -2)[1]", 8},
+		{"(0..3)[3]", nil},
+		{"(0..3)[-1]", nil},
+		{"4 in 0..10 step 2", true},
+		{"5 in 0..10 step 2", false},
+		{"10 in 0..10", false},
+		{"2 in 10..0 step -4", true},
+		{`"a" in 0..10`, false},
+		{"0..4 == range(4)", true},
+		{"len(-9223372036854775807..9223372036854775807 step 2)", 9223372036854775807},
+		{"len(9223372036854775807..-9223372036854775807 step -9223372036854775807)", 2},
+		{"(-9223372036854775807..9223372036854775807 step 2)[9223372036854775806]", 9223372036854775805},
+		{"9223372036854775805 in -9223372036854775807..9223372036854775807 step 2", true},
+		{"9223372036854775806 in -9223372036854775807..9223372036854775807 step 2", false},
+		{"take(0..1000000000, 3)", "[0, 1, 2]"},
+		{"let sum = 0; for i in 1..5 { sum = sum + i; }; sum", 10},
+		{"let n = 6; let sum = 0; for i in 0..n step 2 { sum = sum + i; }; sum", 6},
+		{"let sum = 0; for i in 0..100000 { sum = sum + 1; }; sum", 100000},
+		{"2 in [1, 2, 3]", true},
+		{`"b" in {"a": 1, "b": 2}`, true},
+		{`"ell" in "hello"`, true},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		switch expected := itm.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBoolObject(t, evaluated, expected)
+		case string:
+			if evaluated == nil || evaluated.Inspect() != expected {
+				t.Errorf("%s: expect %s, got %+v", itm.input, expected, evaluated)
+			}
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestRangeErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0..10 step 0", "range step must not be 0"},
+		{`0.."a"`, "range bounds must be INTEGER, got STRING"},
+		{"range()", "wrong number of arguments. got 0, want 1 to 3"},
+		{`(0..3)["a"]`, "range index must be INTEGER, got STRING"},
+		{"1 in 2", "unknow operator: INTEGER in INTEGER"},
+		{
+			"len(-9223372036854775807..9223372036854775807)",
+			"range -9223372036854775807..9223372036854775807 has more elements than an integer can count",
+		},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("expect error, got %T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != itm.expected {
+			t.Errorf("expect error %q, got %q", itm.expected, errObj.Message)
+		}
+	}
+}
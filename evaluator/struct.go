@@ -0,0 +1,108 @@
+package evaluator
+
+import (
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+)
+
+func evalStructStatement(node *ast.StructStatement, env *object.Environement) object.Object {
+	if decl, ok := env.Constant(node.Name.Value); ok {
+		return NewError("%s", parser.ConstantError("redeclare", decl))
+	}
+
+	definition := &object.StructType{Name: node.Name.Value}
+	for _, field := range node.Fields {
+		definition.Fields = append(definition.Fields, field.Value)
+	}
+
+	env.Set(node.Name.Value, definition)
+	return nil
+}
+
+func newStruct(definition *object.StructType, args []object.Object) object.Object {
+	if len(args) != len(definition.Fields) {
+		return NewError("wrong number of arguments to %s. got %d, want %d", definition.Name, len(args), len(definition.Fields))
+	}
+
+	values := make([]object.Object, len(args))
+	copy(values, args)
+
+	return &object.Struct{Definition: definition, Values: values}
+}
+
+func evalStructuralInfixExpression(operator string, left, right object.Object) object.Object {
+	switch operator {
+	case "==":
+		return nativeBooltoToBooleanObject(objectsEqual(left, right))
+	case "!=":
+		return nativeBooltoToBooleanObject(!objectsEqual(left, right))
+	default:
+		return NewError("unknow operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// objectsEqual compares values structurally; objects without a value
+// semantics (functions, hashes, ...) are equal only to themselves.
+func objectsEqual(left, right object.Object) bool {
+	if left.Type() != right.Type() {
+		return false
+	}
+
+	switch left := left.(type) {
+	case *object.Integer:
+		return left.Value == right.(*object.Integer).Value
+	case *object.String:
+		return left.Value == right.(*object.String).Value
+	case *object.Boolean:
+		return left.Value == right.(*object.Boolean).Value
+	case *object.Range:
+		return *left == *right.(*object.Range)
+	case *object.Array:
+		other := right.(*object.Array)
+		if len(left.Elements) != len(other.Elements) {
+			return false
+		}
+		for i := range left.Elements {
+			if !objectsEqual(left.Elements[i], other.Elements[i]) {
+				return false
+			}
+		}
+		return true
+	case *object.Tuple:
+		other := right.(*object.Tuple)
+		if len(left.Elements) != len(other.Elements) {
+			return false
+		}
+		for i := range left.Elements {
+			if !objectsEqual(left.Elements[i], other.Elements[i]) {
+				return false
+			}
+		}
+		return true
+	case *object.Struct:
+		other := right.(*object.Struct)
+		if left.Definition != other.Definition {
+			return false
+		}
+		for i := range left.Values {
+			if !objectsEqual(left.Values[i], other.Values[i]) {
+				return false
+			}
+		}
+		return true
+	case *object.EnumValue:
+		other := right.(*object.EnumValue)
+		if left.Variant != other.Variant {
+			return false
+		}
+		for i := range left.Values {
+			if !objectsEqual(left.Values[i], other.Values[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return left == right
+	}
+}
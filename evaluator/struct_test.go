@@ -0,0 +1,71 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func TestStructs(t *testing.T) {
+	input := `
+	struct Point { x, y }
+	let p = Point(1, 2);
+	p;
+	`
+
+	evaluated := testEval(input)
+	point, ok := evaluated.(*object.Struct)
+	if !ok {
+		t.Fatalf("expect *object.Struct, got %T (%+v)", evaluated, evaluated)
+	}
+
+	if point.Inspect() != "Point{x: 1, y: 2}" {
+		t.Errorf("expect Point{x: 1, y: 2}, got %s", point.Inspect())
+	}
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"struct Point { x, y } let p = Point(1, 2); p.x + p.y;", 3},
+		{"struct Point { x, y } Point(1, 2) == Point(1, 2);", true},
+		{"struct Point { x, y } Point(1, 2) != Point(1, 3);", true},
+		{"struct Point { x, y } Point(1, 2) == Point(2, 1);", false},
+		{"struct A { v } struct B { v } A(1) == B(1);", false},
+		{"struct Line { from, to } struct Point { x, y } Line(Point(0, 0), Point(1, 1)) == Line(Point(0, 0), Point(1, 1));", true},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		switch expected := itm.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBoolObject(t, evaluated, expected)
+		}
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"struct Point { x, y } Point(1, 2).z;", "Point has no field z"},
+		{"struct Point { x, y } Point(1);", "wrong number of arguments to Point. got 1, want 2"},
+		{"struct Point { x, y } Point(1, 2) + Point(1, 2);", "unknow operator: STRUCT + STRUCT"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("expect error, got %T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != itm.expected {
+			t.Errorf("expect error %q, got %q", itm.expected, errObj.Message)
+		}
+	}
+}
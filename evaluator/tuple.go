@@ -0,0 +1,59 @@
+package evaluator
+
+import (
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+)
+
+func evalTupleIndexExpression(left, index object.Object) object.Object {
+	tuple := left.(*object.Tuple)
+	idx := index.(*object.Integer).Value
+
+	if idx < 0 || idx >= int64(len(tuple.Elements)) {
+		return NULL
+	}
+
+	return tuple.Elements[idx]
+}
+
+// let (x, y) = value binds the elements of a tuple or an array by position.
+func evalUnpackStatement(node *ast.UnpackStatement, env *object.Environement) object.Object {
+	for _, name := range node.Names {
+		if decl, ok := env.Constant(name.Value); ok {
+			return withPosition(NewError("%s", parser.ConstantError("redeclare", decl)), name.Token)
+		}
+	}
+
+	val := Eval(node.Value, env)
+	if IsError(val) {
+		return val
+	}
+	if val == nil {
+		val = NULL
+	}
+
+	var elements []object.Object
+	switch val := val.(type) {
+	case *object.Tuple:
+		elements = val.Elements
+	case *object.Array:
+		elements = val.Elements
+	default:
+		return withPosition(NewError("cannot unpack %s", val.Type()), node.Token)
+	}
+
+	if len(elements) != len(node.Names) {
+		return withPosition(NewError("cannot unpack %d values into %d names", len(elements), len(node.Names)), node.Token)
+	}
+
+	for i, name := range node.Names {
+		if node.IsConst() {
+			env.SetConst(name.Value, elements[i], name)
+		} else {
+			env.Set(name.Value, elements[i])
+		}
+	}
+
+	return nil
+}
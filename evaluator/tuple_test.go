@@ -0,0 +1,64 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func TestTuples(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`(1, "a", [2])`, "(1, a, [2])"},
+		{`(1,)`, "(1,)"},
+		{`()`, "()"},
+		{`let t = (1, 2, 3); t[1]`, "2"},
+		{`let t = (1, 2, 3); t[3]`, "null"},
+		{`len((1, 2))`, "2"},
+		{`(1, (2, 3)) == (1, (2, 3))`, "true"},
+		{`(1, 2) != (2, 1)`, "true"},
+		{`2 in (1, 2)`, "true"},
+		{`let divmod = fn(a, b) { return a / b, a - a / b * b; }; divmod(7, 2)`, "(3, 1)"},
+		{`let divmod = fn(a, b) { return a / b, a - a / b * b; }; let (q, r) = divmod(7, 2); q * 10 + r`, "31"},
+		{`let (a, b) = [1, 2]; a + b`, "3"},
+		{`let (a, b) = (1, 2); let (a, b) = (b, a); a`, "2"},
+		{`let h = {(0, 0): "origin", (1, 2): "p"}; h[(1, 2)]`, "p"},
+		{`let h = {(0, "x"): 1}; h[(0, "x")]`, "1"},
+		{`let h = {(0, 0): 1}; (0, 0) in h`, "true"},
+		{`[x for x in (1, 2, 3) if x > 1]`, "[2, 3]"},
+		{`let f = fn(t: tuple) { t[0] }; f((5, 6))`, "5"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		if evaluated == nil || Inspect(evaluated) != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestTupleErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let (a, b) = (1, 2, 3);`, "cannot unpack 3 values into 2 names"},
+		{`let (a, b) = 5;`, "cannot unpack INTEGER"},
+		{`{([1], 2): 1}`, "unusable as hash key: TUPLE"},
+		{`(1, 2) + (3, 4)`, "unknow operator: TUPLE + TUPLE"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != itm.expected {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expected, errObj.Message)
+		}
+	}
+}
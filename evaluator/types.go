@@ -0,0 +1,101 @@
+package evaluator
+
+import (
+	"com.language/monkey/ast"
+	"com.language/monkey/object"
+)
+
+// built-in names usable in annotations
+var annotationTypes = map[string][]object.ObjectType{
+	"int":       {object.INTEGER_OBJ},
+	"string":    {object.STRING_OBJ},
+	"bool":      {object.BOOLEAN_OBJ},
+	"array":     {object.ARRAY_OBJ},
+	"tuple":     {object.TUPLE_OBJ},
+	"hash":      {object.HASH_OBJ},
+	"null":      {object.NULL_OBJ},
+	"fn":        {object.FUNCTION_OBJ, object.BUILTIN_OBJ},
+	"range":     {object.RANGE_OBJ},
+	"generator": {object.GENERATOR_OBJ},
+}
+
+// checkType returns an error when obj does not satisfy annotation, nil
+// otherwise. what names the checked value in the message, e.g. "parameter x".
+func checkType(what string, annotation *ast.Identifier, obj object.Object, env *object.Environement) object.Object {
+	if annotation == nil {
+		return nil
+	}
+	if obj == nil {
+		obj = NULL
+	}
+
+	ok, known := typeMatches(annotation.Value, obj, env)
+	if !known {
+		return NewError("unknown type %s", annotation.Value)
+	}
+	if !ok {
+		return NewError("type mismatch for %s: expected %s, got %s", what, annotation.Value, obj.Type())
+	}
+	return nil
+}
+
+// Names that are not built in resolve in env to a struct or enum type.
+func typeMatches(name string, obj object.Object, env *object.Environement) (ok bool, known bool) {
+	if name == "any" {
+		return true, true
+	}
+
+	if types, found := annotationTypes[name]; found {
+		for _, typ := range types {
+			if obj.Type() == typ {
+				return true, true
+			}
+		}
+		return false, true
+	}
+
+	definition, found := env.Get(name)
+	if !found {
+		return false, false
+	}
+
+	switch definition := definition.(type) {
+	case *object.StructType:
+		value, ok := obj.(*object.Struct)
+		return ok && value.Definition == definition, true
+	case *object.Enum:
+		value, ok := obj.(*object.EnumValue)
+		return ok && value.Variant.Enum == definition, true
+	case *object.Class:
+		instance, ok := obj.(*object.Instance)
+		return ok && instance.Class.IsSubclassOf(definition), true
+	}
+
+	return false, false
+}
+
+func checkArgumentTypes(fn *object.Function, args []object.Object) object.Object {
+	for i, typ := range fn.ParameterTypes {
+		if typ == nil || i >= len(args) {
+			continue
+		}
+		what := "parameter " + fn.Parameters[i].Value
+		if fn.Name != "" {
+			what += " of " + fn.Name
+		}
+		if err := checkType(what, typ, args[i], fn.Env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkReturnType checks what fn returns, the generator itself for a
+// generator function.
+func checkReturnType(fn *object.Function, obj object.Object) object.Object {
+	what := "return value"
+	if fn.Name != "" {
+		what += " of " + fn.Name
+	}
+	return checkType(what, fn.ReturnType, obj, fn.Env)
+}
@@ -0,0 +1,67 @@
+package evaluator
+
+import (
+	"testing"
+
+	"com.language/monkey/object"
+)
+
+func TestTypeAnnotations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let f = fn(x: int, name: string) -> array { [x, name] }; f(1, "a")`, "[1, a]"},
+		{`let f = fn(x, y: any) { y }; f(1, "anything")`, "anything"},
+		{`let n: int = 5; n`, "5"},
+		{`let f: fn = len; f("abc")`, "3"},
+		{`let f = fn(h: hash, xs: array, b: bool, r: range) -> null { if (false) { 1 } }; f({}, [], true, 0..1)`, "null"},
+		{`struct Point { x, y }; let f = fn(p: Point) -> int { p.x }; f(Point(3, 4))`, "3"},
+		{`enum Color { Red, Green }; let f = fn(c: Color) -> Color { c }; f(Color.Red)`, "Color.Red"},
+		{`let f = fn(x) { x }; f("untyped")`, "untyped"},
+		{`fn gen(n) -> generator { yield n }; next(gen(2))`, "2"},
+		{`let gen = fn() -> any { yield "a" }; next(gen())`, "a"},
+		{`let n: int = 5; n += 1; n = n * 2; n`, "12"},
+		{`let n: int = 5; let n = "redeclared"; n = "free"; n`, "free"},
+		{`let n: any = 5; if (true) { n = "x" }; n`, "x"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		if evaluated == nil || evaluated.Inspect() != itm.expected {
+			t.Errorf("%s: expect %s, got %+v", itm.input, itm.expected, evaluated)
+		}
+	}
+}
+
+func TestTypeAnnotationErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let f = fn(x: int, name: string) { x }; f(1, 2)`, "type mismatch for parameter name of f: expected string, got INTEGER"},
+		{`let f = fn(x) -> int { "no" }; f(1)`, "type mismatch for return value of f: expected int, got STRING"},
+		{`let f = fn() -> int { return true; }; f()`, "type mismatch for return value of f: expected int, got BOOLEAN"},
+		{`let n: int = "five";`, "type mismatch for n: expected int, got STRING"},
+		{`struct A { x }; struct B { x }; let f = fn(a: A) { a }; f(B(1))`, "type mismatch for parameter a of f: expected A, got STRUCT"},
+		{`let f = fn(x: number) { x }; f(1)`, "unknown type number"},
+		{`fn(x: int) { x }("a")`, "type mismatch for parameter x: expected int, got STRING"},
+		{`fn() -> int { "no" }()`, "type mismatch for return value: expected int, got STRING"},
+		{`fn gen() -> int { yield 1 }; gen()`, "type mismatch for return value of gen: expected int, got GENERATOR"},
+		{`let n: int = 5; n = "x";`, "type mismatch for n: expected int, got STRING"},
+		{`let n: int = 5; let f = fn() { n = true }; f()`, "type mismatch for n: expected int, got BOOLEAN"},
+		{`let f = fn(x: int) { x = "s" }; f(1)`, "type mismatch for x: expected int, got STRING"},
+	}
+
+	for _, itm := range tests {
+		evaluated := testEval(itm.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expect error, got %T (%+v)", itm.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != itm.expected {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expected, errObj.Message)
+		}
+	}
+}
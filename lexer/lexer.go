@@ -3,6 +3,7 @@ package lexer
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"com.language/monkey/token"
 )
@@ -12,30 +13,74 @@ type Lexer struct {
 	Position     int
 	ReadPosition int
 	ch           byte
+
+	line   int
+	column int
+
+	// tokens registered by embedders, see Register
+	keywords  map[string]token.TokenType
+	operators map[string]token.TokenType
 }
 
 func (l *Lexer) NextToken() token.Token {
 	var tok token.Token
 	// skip space
-	l.skipWhiteSpace()
+	newline := l.skipWhiteSpace()
+	line, column := l.line, l.column
+	if op, ok := l.matchOperator(); ok {
+		for i := 1; i < len(op); i++ {
+			l.readChar()
+		}
+		tok = NewToken(l.operators[op], op)
+		l.readChar()
+		tok.Line, tok.Column, tok.NewlineBefore = line, column, newline
+		return tok
+	}
+
 	switch l.ch {
 	case '=':
 		if l.peekChar() == '=' {
 			l.readChar()
 			tok = NewToken(token.EQUAL, "==")
+		} else if l.peekChar() == '>' {
+			l.readChar()
+			tok = NewToken(token.FATARROW, "=>")
 		} else {
 			tok = NewToken(token.ASSIGN, "=")
 		}
 	case '+':
-		tok = NewToken(token.PLUS, "+")
+		if l.peekChar() == '=' {
+			l.readChar()
+			tok = NewToken(token.PLUS_ASSIGN, "+=")
+		} else {
+			tok = NewToken(token.PLUS, "+")
+		}
 	case ',':
 		tok = NewToken(token.COMMA, ",")
 	case '-':
-		tok = NewToken(token.MINUS, "-")
+		if l.peekChar() == '>' {
+			l.readChar()
+			tok = NewToken(token.ARROW, "->")
+		} else if l.peekChar() == '=' {
+			l.readChar()
+			tok = NewToken(token.MINUS_ASSIGN, "-=")
+		} else {
+			tok = NewToken(token.MINUS, "-")
+		}
 	case '*':
-		tok = NewToken(token.ASTERISK, "*")
+		if l.peekChar() == '=' {
+			l.readChar()
+			tok = NewToken(token.ASTERISK_ASSIGN, "*=")
+		} else {
+			tok = NewToken(token.ASTERISK, "*")
+		}
 	case '/':
-		tok = NewToken(token.SLASH, "/")
+		if l.peekChar() == '=' {
+			l.readChar()
+			tok = NewToken(token.SLASH_ASSIGN, "/=")
+		} else {
+			tok = NewToken(token.SLASH, "/")
+		}
 	case '<':
 		if l.peekChar() == '=' {
 			l.readChar()
@@ -64,6 +109,13 @@ func (l *Lexer) NextToken() token.Token {
 		tok = NewToken(token.SEMICOLON, ";")
 	case ':':
 		tok = NewToken(token.COLON, ":")
+	case '.':
+		if l.peekChar() == '.' {
+			l.readChar()
+			tok = NewToken(token.DOTDOT, "..")
+		} else {
+			tok = NewToken(token.DOT, ".")
+		}
 	case '(':
 		tok = NewToken(token.LPAREN, "(")
 	case ')':
@@ -84,11 +136,13 @@ func (l *Lexer) NextToken() token.Token {
 	default:
 		if IsLitter(l.ch) {
 			tok.Literal = l.readIdentifier()
-			tok.Type = token.LoopupIdentifier(tok.Literal)
+			tok.Type = l.lookupIdentifier(tok.Literal)
+			tok.Line, tok.Column, tok.NewlineBefore = line, column, newline
 			return tok
 		} else if IsDigital(l.ch) {
 			tok.Literal = l.readDigital()
 			tok.Type = token.INT
+			tok.Line, tok.Column, tok.NewlineBefore = line, column, newline
 			return tok
 		} else {
 			tok = NewToken(token.ILLEGAL, string(l.ch))
@@ -96,9 +150,62 @@ func (l *Lexer) NextToken() token.Token {
 		}
 	}
 	l.readChar()
+	tok.Line, tok.Column, tok.NewlineBefore = line, column, newline
 	return tok
 }
 
+// Register adds a token to this lexer. A literal made of letters becomes a
+// keyword, anything else an operator. Operators are matched longest first,
+// before the built-in ones, so "**" wins over "*".
+func (l *Lexer) Register(literal string, tokType token.TokenType) {
+	if literal == "" {
+		return
+	}
+
+	if IsLitter(literal[0]) {
+		if l.keywords == nil {
+			l.keywords = map[string]token.TokenType{}
+		}
+		l.keywords[literal] = tokType
+		return
+	}
+
+	if l.operators == nil {
+		l.operators = map[string]token.TokenType{}
+	}
+	l.operators[literal] = tokType
+}
+
+// Reset rewinds the lexer to the start of its input.
+func (l *Lexer) Reset() {
+	l.Position = 0
+	l.ReadPosition = 0
+	l.ch = 0
+	l.line = 1
+	l.column = 0
+	l.readChar()
+}
+
+func (l *Lexer) matchOperator() (string, bool) {
+	longest := ""
+	if l.Position >= len(l.Input) {
+		return longest, false
+	}
+	for op := range l.operators {
+		if len(op) > len(longest) && strings.HasPrefix(l.Input[l.Position:], op) {
+			longest = op
+		}
+	}
+	return longest, longest != ""
+}
+
+func (l *Lexer) lookupIdentifier(ident string) token.TokenType {
+	if tkType, ok := l.keywords[ident]; ok {
+		return tkType
+	}
+	return token.LoopupIdentifier(ident)
+}
+
 func (l *Lexer) readIdentifier() string {
 	position := l.Position
 	for IsLitter(l.ch) {
@@ -130,6 +237,12 @@ func (l *Lexer) readDigital() string {
 }
 
 func (l *Lexer) readChar() {
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	}
+	l.column++
+
 	if l.ReadPosition < len(l.Input) {
 		l.ch = l.Input[l.ReadPosition]
 	} else {
@@ -146,10 +259,16 @@ func (l *Lexer) peekChar() byte {
 	return 0
 }
 
-func (l *Lexer) skipWhiteSpace() {
+// skipWhiteSpace reports whether it crossed a line break.
+func (l *Lexer) skipWhiteSpace() bool {
+	newline := false
 	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+		if l.ch == '\n' {
+			newline = true
+		}
 		l.readChar()
 	}
+	return newline
 }
 
 func IsLitter(ch byte) bool {
@@ -171,6 +290,7 @@ func NewToken(tType token.TokenType, literal string) token.Token {
 func New(input string) *Lexer {
 	lex := &Lexer{
 		Input: input,
+		line:  1,
 	}
 	lex.readChar()
 	return lex
@@ -180,6 +180,9 @@ func TestDoubleOperation(t *testing.T) {
 	10 != 9
 	10 <= 11
 	10 >= 9
+	x => 1
+	fn(x: int) -> int
+	x += 1 -= 2 *= 3 /= 4
 	`
 
 	tests := []struct {
@@ -199,9 +202,174 @@ func TestDoubleOperation(t *testing.T) {
 		{token.INT, "10"},
 		{token.GEQ, ">="},
 		{token.INT, "9"},
+		{token.IDENT, "x"},
+		{token.FATARROW, "=>"},
+		{token.INT, "1"},
+		{token.FUNCTION, "fn"},
+		{token.LPAREN, "("},
+		{token.IDENT, "x"},
+		{token.COLON, ":"},
+		{token.IDENT, "int"},
+		{token.RPAREN, ")"},
+		{token.ARROW, "->"},
+		{token.IDENT, "int"},
+		{token.IDENT, "x"},
+		{token.PLUS_ASSIGN, "+="},
+		{token.INT, "1"},
+		{token.MINUS_ASSIGN, "-="},
+		{token.INT, "2"},
+		{token.ASTERISK_ASSIGN, "*="},
+		{token.INT, "3"},
+		{token.SLASH_ASSIGN, "/="},
+		{token.INT, "4"},
+	}
+
+	l := New(input)
+
+	for _, itm := range tests {
+		token := l.NextToken()
+
+		if itm.expectType != token.Type {
+			t.Errorf("expect type: %s, real type: %v", itm.expectType, token.Type)
+		}
+
+		if itm.expectLeteral != token.Literal {
+			t.Errorf("expect literal: %s, real literal: %v", itm.expectLeteral, token.Literal)
+		}
+	}
+}
+
+func TestMacroKeyword(t *testing.T) {
+	input := `macro(x, y) { x + y; };`
+
+	tests := []struct {
+		expectType    token.TokenType
+		expectLeteral string
+	}{
+		{token.MACRO, "macro"},
+		{token.LPAREN, "("},
+		{token.IDENT, "x"},
+		{token.COMMA, ","},
+		{token.IDENT, "y"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.IDENT, "x"},
+		{token.PLUS, "+"},
+		{token.IDENT, "y"},
+		{token.SEMICOLON, ";"},
+		{token.RBRACE, "}"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for _, itm := range tests {
+		token := l.NextToken()
+
+		if itm.expectType != token.Type {
+			t.Errorf("expect type: %s, real type: %v", itm.expectType, token.Type)
+		}
+
+		if itm.expectLeteral != token.Literal {
+			t.Errorf("expect literal: %s, real literal: %v", itm.expectLeteral, token.Literal)
+		}
+	}
+}
+
+func TestTokenPosition(t *testing.T) {
+	input := `let x = 5;
+  const name = "monkey";`
+
+	tests := []struct {
+		expectLeteral string
+		expectLine    int
+		expectColumn  int
+	}{
+		{"let", 1, 1},
+		{"x", 1, 5},
+		{"=", 1, 7},
+		{"5", 1, 9},
+		{";", 1, 10},
+		{"const", 2, 3},
+		{"name", 2, 9},
+		{"=", 2, 14},
+		{"monkey", 2, 16},
+		{";", 2, 24},
+	}
+
+	l := New(input)
+
+	for _, itm := range tests {
+		token := l.NextToken()
+
+		if itm.expectLeteral != token.Literal {
+			t.Errorf("expect literal: %s, real literal: %v", itm.expectLeteral, token.Literal)
+		}
+
+		if itm.expectLine != token.Line || itm.expectColumn != token.Column {
+			t.Errorf("%s: expect position %d:%d, got %d:%d", token.Literal, itm.expectLine, itm.expectColumn, token.Line, token.Column)
+		}
+	}
+}
+
+func TestRangeOperator(t *testing.T) {
+	input := `0..n step 2; a.b`
+
+	tests := []struct {
+		expectType    token.TokenType
+		expectLeteral string
+	}{
+		{token.INT, "0"},
+		{token.DOTDOT, ".."},
+		{token.IDENT, "n"},
+		{token.IDENT, "step"},
+		{token.INT, "2"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "a"},
+		{token.DOT, "."},
+		{token.IDENT, "b"},
+	}
+
+	l := New(input)
+
+	for _, itm := range tests {
+		token := l.NextToken()
+
+		if itm.expectType != token.Type {
+			t.Errorf("expect type: %s, real type: %v", itm.expectType, token.Type)
+		}
+
+		if itm.expectLeteral != token.Literal {
+			t.Errorf("expect literal: %s, real literal: %v", itm.expectLeteral, token.Literal)
+		}
 	}
+}
+
+func TestRegisteredTokens(t *testing.T) {
+	input := `a |> b ** 2 * c; unless x`
 
 	l := New(input)
+	l.Register("|>", "|>")
+	l.Register("**", "**")
+	l.Register("unless", "UNLESS")
+
+	tests := []struct {
+		expectType    token.TokenType
+		expectLeteral string
+	}{
+		{token.IDENT, "a"},
+		{"|>", "|>"},
+		{token.IDENT, "b"},
+		{"**", "**"},
+		{token.INT, "2"},
+		{token.ASTERISK, "*"},
+		{token.IDENT, "c"},
+		{token.SEMICOLON, ";"},
+		{"UNLESS", "unless"},
+		{token.IDENT, "x"},
+		{token.EOF, ""},
+	}
 
 	for _, itm := range tests {
 		token := l.NextToken()
@@ -215,3 +383,39 @@ func TestDoubleOperation(t *testing.T) {
 		}
 	}
 }
+
+func TestNewlineBefore(t *testing.T) {
+	input := "let x = 1\nlet y = [\n  2, 3\n]"
+
+	tests := []struct {
+		expectLeteral string
+		expectNewline bool
+	}{
+		{"let", false},
+		{"x", false},
+		{"=", false},
+		{"1", false},
+		{"let", true},
+		{"y", false},
+		{"=", false},
+		{"[", false},
+		{"2", true},
+		{",", false},
+		{"3", false},
+		{"]", true},
+	}
+
+	l := New(input)
+
+	for _, itm := range tests {
+		token := l.NextToken()
+
+		if itm.expectLeteral != token.Literal {
+			t.Errorf("expect literal: %s, real literal: %v", itm.expectLeteral, token.Literal)
+		}
+
+		if itm.expectNewline != token.NewlineBefore {
+			t.Errorf("%s: expect NewlineBefore %v, got %v", token.Literal, itm.expectNewline, token.NewlineBefore)
+		}
+	}
+}
@@ -1,7 +1,20 @@
 package main
 
-import "com.language/monkey/repl"
+import (
+	"flag"
+
+	"com.language/monkey/evaluator"
+	"com.language/monkey/repl"
+)
 
 func main() {
+	noContracts := flag.Bool("no-contracts", false, "skip requires/ensures checks")
+	flag.Parse()
+	evaluator.ContractsEnabled = !*noContracts
+
+	if flag.NArg() > 0 {
+		repl.RunFile(flag.Arg(0))
+		return
+	}
 	repl.Repl()
 }
@@ -0,0 +1,117 @@
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Class is what `class Name { ... }` binds to Name; calling it builds an Instance.
+type Class struct {
+	Name    string
+	Super   *Class
+	Methods map[string]*Function
+}
+
+func (c *Class) Type() ObjectType {
+	return CLASS_OBJ
+}
+
+func (c *Class) Inspect() string {
+	return "<class " + c.Name + ">"
+}
+
+// Lookup finds a method on c or its ancestors, along with the class defining it.
+func (c *Class) Lookup(name string) (*Function, *Class) {
+	for class := c; class != nil; class = class.Super {
+		if method, ok := class.Methods[name]; ok {
+			return method, class
+		}
+	}
+	return nil, nil
+}
+
+// IsSubclassOf reports whether c is other or inherits from it.
+func (c *Class) IsSubclassOf(other *Class) bool {
+	for class := c; class != nil; class = class.Super {
+		if class == other {
+			return true
+		}
+	}
+	return false
+}
+
+type Instance struct {
+	Class  *Class
+	Fields map[string]Object
+	// field names in the order they were first assigned
+	Names []string
+}
+
+func NewInstance(class *Class) *Instance {
+	return &Instance{Class: class, Fields: map[string]Object{}}
+}
+
+func (i *Instance) Type() ObjectType {
+	return INSTANCE_OBJ
+}
+
+func (i *Instance) Inspect() string {
+	var out bytes.Buffer
+
+	fields := []string{}
+	for _, name := range i.Names {
+		fields = append(fields, name+": "+i.Fields[name].Inspect())
+	}
+
+	out.WriteString(i.Class.Name)
+	out.WriteString("{")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+func (i *Instance) Get(name string) (Object, bool) {
+	val, ok := i.Fields[name]
+	return val, ok
+}
+
+func (i *Instance) Set(name string, val Object) Object {
+	if _, ok := i.Fields[name]; !ok {
+		i.Names = append(i.Names, name)
+	}
+	i.Fields[name] = val
+	return val
+}
+
+// BoundMethod is a method looked up on an instance; calling it binds self.
+type BoundMethod struct {
+	Receiver *Instance
+	Method   *Function
+	// the class that defines Method, where super lookups start from
+	Owner *Class
+}
+
+func (bm *BoundMethod) Type() ObjectType {
+	return BOUND_METHOD_OBJ
+}
+
+func (bm *BoundMethod) Inspect() string {
+	return fmt.Sprintf("<method %s.%s/%d>", bm.Owner.Name, bm.Method.Name, len(bm.Method.Parameters))
+}
+
+// Super is what `super` refers to inside a method: the receiver seen
+// through the superclass of the method's class.
+type Super struct {
+	Receiver *Instance
+	Class    *Class
+}
+
+func (s *Super) Type() ObjectType {
+	return SUPER_OBJ
+}
+
+func (s *Super) Inspect() string {
+	return "<super " + s.Class.Name + ">"
+}
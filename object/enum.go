@@ -0,0 +1,87 @@
+package object
+
+import (
+	"strings"
+)
+
+// Enum is what `enum Shape { ... }` binds to Shape.
+type Enum struct {
+	Name     string
+	Variants []*EnumVariant
+}
+
+func (e *Enum) Type() ObjectType {
+	return ENUM_OBJ
+}
+
+func (e *Enum) Inspect() string {
+	variants := []string{}
+	for _, variant := range e.Variants {
+		variants = append(variants, variant.Inspect())
+	}
+	return "enum " + e.Name + " { " + strings.Join(variants, ", ") + " }"
+}
+
+func (e *Enum) Variant(name string) (*EnumVariant, bool) {
+	for _, variant := range e.Variants {
+		if variant.Name == name {
+			return variant, true
+		}
+	}
+	return nil, false
+}
+
+// EnumVariant is the constructor of one case of an enum.
+type EnumVariant struct {
+	Enum   *Enum
+	Name   string
+	Fields []string
+	// variants without payload are values, not constructors
+	Unit bool
+}
+
+func (ev *EnumVariant) Type() ObjectType {
+	return ENUM_VARIANT_OBJ
+}
+
+func (ev *EnumVariant) Inspect() string {
+	if ev.Unit {
+		return ev.Name
+	}
+	return ev.Name + "(" + strings.Join(ev.Fields, ", ") + ")"
+}
+
+func (ev *EnumVariant) FullName() string {
+	return ev.Enum.Name + "." + ev.Name
+}
+
+type EnumValue struct {
+	Variant *EnumVariant
+	// in the order of Variant.Fields
+	Values []Object
+}
+
+func (ev *EnumValue) Type() ObjectType {
+	return ENUM_VALUE_OBJ
+}
+
+func (ev *EnumValue) Inspect() string {
+	if ev.Variant.Unit {
+		return ev.Variant.FullName()
+	}
+
+	values := []string{}
+	for _, val := range ev.Values {
+		values = append(values, val.Inspect())
+	}
+	return ev.Variant.FullName() + "(" + strings.Join(values, ", ") + ")"
+}
+
+func (ev *EnumValue) Get(field string) (Object, bool) {
+	for i, name := range ev.Variant.Fields {
+		if name == field {
+			return ev.Values[i], true
+		}
+	}
+	return nil, false
+}
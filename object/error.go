@@ -1,7 +1,19 @@
 package object
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Error struct {
 	Message string
+	// what `throw` was given, nil for errors raised by the interpreter
+	Value Object
+	// where the error was raised, 0 when unknown
+	Line   int
+	Column int
+	// the calls the error unwound through, innermost first
+	Trace []string
 }
 
 func (e *Error) Type() ObjectType {
@@ -9,5 +21,17 @@ func (e *Error) Type() ObjectType {
 }
 
 func (e *Error) Inspect() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("ERROR: %s (line %d, column %d)", e.Message, e.Line, e.Column)
+	}
 	return "ERROR: " + e.Message
 }
+
+// StackTrace renders Trace one frame per line, empty when there is none.
+func (e *Error) StackTrace() string {
+	var out strings.Builder
+	for _, frame := range e.Trace {
+		out.WriteString("  at " + frame + "\n")
+	}
+	return out.String()
+}
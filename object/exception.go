@@ -0,0 +1,23 @@
+package object
+
+import "fmt"
+
+// Exception is a caught Error as seen by the catch block. Unlike Error it
+// is an ordinary value and doesn't unwind the stack until thrown again.
+type Exception struct {
+	Message string
+	Value   Object
+	Line    int
+	Column  int
+}
+
+func (e *Exception) Type() ObjectType {
+	return EXCEPTION_OBJ
+}
+
+func (e *Exception) Inspect() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("exception: %s (line %d, column %d)", e.Message, e.Line, e.Column)
+	}
+	return "exception: " + e.Message
+}
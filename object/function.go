@@ -1,36 +1,33 @@
 package object
 
 import (
-	"bytes"
-	"strings"
+	"fmt"
 
 	"com.language/monkey/ast"
 )
 
 type Function struct {
+	// the declared or let-bound name, empty for anonymous functions
+	Name       string
 	Parameters []*ast.Identifier
-	Body       *ast.BlockStatements
-	Env        *Environement
+	// annotations, nil where absent
+	ParameterTypes []*ast.Identifier
+	ReturnType     *ast.Identifier
+	Requires       []ast.Expression
+	Ensures        []ast.Expression
+	Body           *ast.BlockStatements
+	Env            *Environement
+	IsGenerator    bool
 }
 
 func (f *Function) Type() ObjectType {
 	return FUNCTION_OBJ
 }
 
+// Inspect prints the name and arity, e.g. <fn fact/1>.
 func (f *Function) Inspect() string {
-	var out bytes.Buffer
-	params := []string{}
-
-	for _, itm := range f.Parameters {
-		params = append(params, itm.String())
+	if f.Name == "" {
+		return fmt.Sprintf("<fn/%d>", len(f.Parameters))
 	}
-
-	out.WriteString("fn")
-	out.WriteString("(")
-	out.WriteString(strings.Join(params, ", "))
-	out.WriteString(") {\n")
-	out.WriteString(f.Body.String())
-	out.WriteString("\n}")
-
-	return out.String()
+	return fmt.Sprintf("<fn %s/%d>", f.Name, len(f.Parameters))
 }
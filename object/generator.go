@@ -0,0 +1,27 @@
+package object
+
+// Generator is returned by calling a function whose body contains yield.
+// The evaluator drives the suspended body through the two hooks.
+type Generator struct {
+	Function *Function
+	// Next resumes the body until the next yield and returns the yielded
+	// value. done is true once the body has finished, the value then is
+	// NULL or the error the body failed with.
+	Next func() (value Object, done bool)
+	// Yield hands a value to the caller of Next and suspends the body. It
+	// returns nil when the body is resumed, or the error the body unwinds
+	// with once the generator is stopped.
+	Yield func(Object) Object
+	// Stop abandons a generator that is not run to the end; the suspended
+	// body unwinds, running its deferred calls and finally blocks, and later
+	// calls of Next are done.
+	Stop func()
+}
+
+func (g *Generator) Type() ObjectType {
+	return GENERATOR_OBJ
+}
+
+func (g *Generator) Inspect() string {
+	return "<generator>"
+}
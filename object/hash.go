@@ -11,6 +11,21 @@ type HashTable interface {
 	HashKey() HashKey
 }
 
+// Hashable returns obj as a HashTable if it can be used as a hash key; a
+// tuple qualifies only when all of its elements do.
+func Hashable(obj Object) (HashTable, bool) {
+	if tuple, ok := obj.(*Tuple); ok {
+		for _, element := range tuple.Elements {
+			if _, ok := Hashable(element); !ok {
+				return nil, false
+			}
+		}
+	}
+
+	key, ok := obj.(HashTable)
+	return key, ok
+}
+
 type HashKey struct {
 	Type  ObjectType
 	Value int64
@@ -45,6 +60,20 @@ type HashPair struct {
 
 type Hash struct {
 	Pairs map[HashKey]HashPair
+	// keys of Pairs in insertion order
+	Keys []HashKey
+}
+
+func NewHash() *Hash {
+	return &Hash{Pairs: make(map[HashKey]HashPair)}
+}
+
+// Set adds or replaces a pair; a replaced key keeps its original position.
+func (h *Hash) Set(key HashKey, pair HashPair) {
+	if _, ok := h.Pairs[key]; !ok {
+		h.Keys = append(h.Keys, key)
+	}
+	h.Pairs[key] = pair
 }
 
 func (h *Hash) Type() ObjectType {
@@ -56,7 +85,8 @@ func (h *Hash) Inspect() string {
 
 	pairs := []string{}
 
-	for _, pair := range h.Pairs {
+	for _, key := range h.Keys {
+		pair := h.Pairs[key]
 		pairs = append(pairs, fmt.Sprintf("%s:%s", pair.Key.Inspect(), pair.Value.Inspect()))
 	}
 
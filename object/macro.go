@@ -0,0 +1,36 @@
+package object
+
+import (
+	"bytes"
+	"strings"
+
+	"com.language/monkey/ast"
+)
+
+type Macro struct {
+	Parameters []*ast.Identifier
+	Body       *ast.BlockStatements
+	Env        *Environement
+}
+
+func (m *Macro) Type() ObjectType {
+	return MACRO_OBJ
+}
+
+func (m *Macro) Inspect() string {
+	var out bytes.Buffer
+	params := []string{}
+
+	for _, itm := range m.Parameters {
+		params = append(params, itm.String())
+	}
+
+	out.WriteString("macro")
+	out.WriteString("(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(") {\n")
+	out.WriteString(m.Body.String())
+	out.WriteString("\n}")
+
+	return out.String()
+}
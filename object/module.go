@@ -0,0 +1,51 @@
+package object
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+type Module struct {
+	Name    string
+	Path    string
+	Env     *Environement
+	Exports map[string]Object
+}
+
+func (m *Module) Type() ObjectType {
+	return MODULE_OBJ
+}
+
+func (m *Module) Inspect() string {
+	var out bytes.Buffer
+
+	names := []string{}
+	for name := range m.Exports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out.WriteString("<module " + m.Name)
+	if len(names) > 0 {
+		out.WriteString(" exports " + strings.Join(names, ", "))
+	}
+	out.WriteString(">")
+
+	return out.String()
+}
+
+func NewModuleEnvironment(module *Module) *Environement {
+	env := NewEnvironment()
+	env.module = module
+	module.Env = env
+	return env
+}
+
+// Module returns the module whose code is running in this environment, nil for the REPL.
+func (e *Environement) Module() *Module {
+	if e.module == nil && e.outer != nil {
+		return e.outer.Module()
+	}
+	return e.module
+}
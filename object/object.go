@@ -1,5 +1,7 @@
 package object
 
+import "com.language/monkey/ast"
+
 type ObjectType string
 
 const (
@@ -13,6 +15,22 @@ const (
 	BUILTIN_OBJ      = "BUILTIN"
 	ARRAY_OBJ        = "ARRAY"
 	HASH_OBJ         = "HASH"
+	QUOTE_OBJ        = "QUOTE"
+	MACRO_OBJ        = "MACRO"
+	MODULE_OBJ       = "MODULE"
+	STRUCT_TYPE_OBJ  = "STRUCT_TYPE"
+	STRUCT_OBJ       = "STRUCT"
+	ENUM_OBJ         = "ENUM"
+	ENUM_VARIANT_OBJ = "ENUM_VARIANT"
+	ENUM_VALUE_OBJ   = "ENUM_VALUE"
+	EXCEPTION_OBJ    = "EXCEPTION"
+	GENERATOR_OBJ    = "GENERATOR"
+	RANGE_OBJ        = "RANGE"
+	CLASS_OBJ        = "CLASS"
+	INSTANCE_OBJ     = "INSTANCE"
+	BOUND_METHOD_OBJ = "BOUND_METHOD"
+	SUPER_OBJ        = "SUPER"
+	TUPLE_OBJ        = "TUPLE"
 )
 
 type Object interface {
@@ -35,8 +53,81 @@ func NewEnvironment() *Environement {
 }
 
 type Environement struct {
-	store map[string]Object
-	outer *Environement
+	store  map[string]Object
+	outer  *Environement
+	module *Module
+	// names bound by const in this scope, with where they were declared
+	consts map[string]*ast.Identifier
+	// type annotations of names bound in this scope, checked on assignment
+	annotations map[string]*ast.Identifier
+	// set on the scope of a function call, which owns the deferred calls
+	function  bool
+	deferred  []Deferred
+	generator *Generator
+	// language features chosen by `use` pragmas of the file this scope belongs to
+	features map[string]bool
+}
+
+// Deferred is a `defer` expression waiting for its function to return.
+type Deferred struct {
+	Call ast.Expression
+	Env  *Environement
+}
+
+// NewFunctionEnvironment creates the scope of one function call.
+func NewFunctionEnvironment(outer *Environement) *Environement {
+	env := NewEnclosedEnvironment(outer)
+	env.function = true
+	return env
+}
+
+// Defer registers call on the innermost enclosing function scope. It
+// reports false when there is none.
+func (e *Environement) Defer(call ast.Expression, env *Environement) bool {
+	for scope := e; scope != nil; scope = scope.outer {
+		if scope.function {
+			scope.deferred = append(scope.deferred, Deferred{Call: call, Env: env})
+			return true
+		}
+	}
+	return false
+}
+
+// SetGenerator marks the function scope as the body of gen.
+func (e *Environement) SetGenerator(gen *Generator) {
+	e.generator = gen
+}
+
+// Generator returns the generator whose body runs in the innermost enclosing
+// function scope, nil when that function isn't a generator.
+func (e *Environement) Generator() *Generator {
+	for scope := e; scope != nil; scope = scope.outer {
+		if scope.function {
+			return scope.generator
+		}
+	}
+	return nil
+}
+
+// SetFeatures records the features enabled for code running in this scope and the scopes it encloses.
+func (e *Environement) SetFeatures(features map[string]bool) {
+	e.features = features
+}
+
+// Features returns the features set on the innermost scope that has any,
+// nil when no scope does.
+func (e *Environement) Features() map[string]bool {
+	for scope := e; scope != nil; scope = scope.outer {
+		if scope.features != nil {
+			return scope.features
+		}
+	}
+	return nil
+}
+
+// Deferred returns the calls deferred in this function scope, in registration order.
+func (e *Environement) Deferred() []Deferred {
+	return e.deferred
 }
 
 func (e *Environement) Get(name string) (Object, bool) {
@@ -51,3 +142,46 @@ func (e *Environement) Set(name string, obj Object) Object {
 	e.store[name] = obj
 	return obj
 }
+
+// SetConst binds name like Set and marks it as constant in this scope.
+func (e *Environement) SetConst(name string, obj Object, decl *ast.Identifier) Object {
+	if e.consts == nil {
+		e.consts = make(map[string]*ast.Identifier)
+	}
+	e.consts[name] = decl
+	return e.Set(name, obj)
+}
+
+// Constant reports whether name is a constant of this scope (outer scopes are not consulted).
+func (e *Environement) Constant(name string) (*ast.Identifier, bool) {
+	decl, ok := e.consts[name]
+	return decl, ok
+}
+
+// Annotate records the type annotation of name in this scope; nil removes it.
+func (e *Environement) Annotate(name string, annotation *ast.Identifier) {
+	if annotation == nil {
+		delete(e.annotations, name)
+		return
+	}
+	if e.annotations == nil {
+		e.annotations = make(map[string]*ast.Identifier)
+	}
+	e.annotations[name] = annotation
+}
+
+// Annotation returns the type annotation of name in this scope (outer scopes are not consulted).
+func (e *Environement) Annotation(name string) *ast.Identifier {
+	return e.annotations[name]
+}
+
+// Resolve returns the innermost environment that binds name.
+func (e *Environement) Resolve(name string) (*Environement, bool) {
+	if _, ok := e.store[name]; ok {
+		return e, true
+	}
+	if e.outer != nil {
+		return e.outer.Resolve(name)
+	}
+	return nil, false
+}
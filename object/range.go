@@ -0,0 +1,60 @@
+package object
+
+import (
+	"fmt"
+	"math"
+)
+
+// Range is the lazy sequence Start, Start+Step, ... up to but excluding End.
+type Range struct {
+	Start int64
+	End   int64
+	Step  int64
+}
+
+func (r *Range) Type() ObjectType {
+	return RANGE_OBJ
+}
+
+func (r *Range) Inspect() string {
+	if r.Step == 1 {
+		return fmt.Sprintf("%d..%d", r.Start, r.End)
+	}
+	return fmt.Sprintf("%d..%d step %d", r.Start, r.End, r.Step)
+}
+
+// Len returns the number of elements; ok is false when that does not fit
+// an int64, n is then math.MaxInt64.
+func (r *Range) Len() (n int64, ok bool) {
+	// the distance between two int64 always fits an uint64
+	var span, step uint64
+	switch {
+	case r.Step > 0 && r.End > r.Start:
+		span, step = uint64(r.End)-uint64(r.Start), uint64(r.Step)
+	case r.Step < 0 && r.End < r.Start:
+		span, step = uint64(r.Start)-uint64(r.End), -uint64(r.Step)
+	default:
+		return 0, true
+	}
+
+	count := (span-1)/step + 1
+	if count > math.MaxInt64 {
+		return math.MaxInt64, false
+	}
+	return int64(count), true
+}
+
+// At returns the i-th element, ok is false when i is out of bounds.
+func (r *Range) At(i int64) (int64, bool) {
+	if n, _ := r.Len(); i < 0 || i >= n {
+		return 0, false
+	}
+	return r.Start + i*r.Step, true
+}
+
+func (r *Range) Contains(n int64) bool {
+	if r.Step > 0 {
+		return n >= r.Start && n < r.End && (uint64(n)-uint64(r.Start))%uint64(r.Step) == 0
+	}
+	return n <= r.Start && n > r.End && (uint64(r.Start)-uint64(n))%-uint64(r.Step) == 0
+}
@@ -0,0 +1,55 @@
+package object
+
+import (
+	"bytes"
+	"strings"
+)
+
+// StructType is what `struct Point { x, y }` binds to Point; calling it builds a Struct.
+type StructType struct {
+	Name   string
+	Fields []string
+}
+
+func (st *StructType) Type() ObjectType {
+	return STRUCT_TYPE_OBJ
+}
+
+func (st *StructType) Inspect() string {
+	return "struct " + st.Name + " { " + strings.Join(st.Fields, ", ") + " }"
+}
+
+type Struct struct {
+	Definition *StructType
+	// in the order of Definition.Fields
+	Values []Object
+}
+
+func (s *Struct) Type() ObjectType {
+	return STRUCT_OBJ
+}
+
+func (s *Struct) Inspect() string {
+	var out bytes.Buffer
+
+	fields := []string{}
+	for i, name := range s.Definition.Fields {
+		fields = append(fields, name+": "+s.Values[i].Inspect())
+	}
+
+	out.WriteString(s.Definition.Name)
+	out.WriteString("{")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+func (s *Struct) Get(field string) (Object, bool) {
+	for i, name := range s.Definition.Fields {
+		if name == field {
+			return s.Values[i], true
+		}
+	}
+	return nil, false
+}
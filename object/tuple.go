@@ -0,0 +1,47 @@
+package object
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/fnv"
+	"strings"
+)
+
+// Tuple is an immutable, fixed-size sequence of values.
+type Tuple struct {
+	Elements []Object
+}
+
+func (t *Tuple) Type() ObjectType {
+	return TUPLE_OBJ
+}
+
+func (t *Tuple) Inspect() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+
+	for _, itm := range t.Elements {
+		elements = append(elements, itm.Inspect())
+	}
+	out.WriteString("(")
+	out.WriteString(strings.Join(elements, ", "))
+	if len(elements) == 1 {
+		out.WriteString(",")
+	}
+	out.WriteString(")")
+	return out.String()
+}
+
+// HashKey combines the keys of the elements; callers check Hashable first.
+func (t *Tuple) HashKey() HashKey {
+	h := fnv.New64a()
+
+	for _, element := range t.Elements {
+		key := element.(HashTable).HashKey()
+		h.Write([]byte(key.Type))
+		binary.Write(h, binary.LittleEndian, key.Value)
+	}
+
+	return HashKey{Type: t.Type(), Value: int64(h.Sum64())}
+}
@@ -3,8 +3,10 @@ package parser
 const (
 	_ int = iota
 	LOWEST
-	EQUALS      //=
+	ASSIGN      // x = y
+	EQUALS      //==
 	LESSGREATER // <  or >
+	RANGE       // 0..10
 	SUM         // + -
 	PRODUCT     // 5*5 ,  10/2
 	PREFIX      // -X or !X
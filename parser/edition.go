@@ -0,0 +1,204 @@
+package parser
+
+import (
+	"fmt"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/token"
+)
+
+// LatestEdition is the edition of a file without a `use edition` pragma.
+const LatestEdition = "2026"
+
+// features that can be switched on one by one with `use feature "name";`,
+// everything the language gained after the 2024 edition
+var featureNames = []string{
+	"macros",
+	"modules",
+	"constants",
+	"assignment",
+	"member-access",
+	"structs",
+	"enums",
+	"match",
+	"exceptions",
+	"defer",
+	"generators",
+	"loops",
+	"ranges",
+	"comprehensions",
+	"types",
+	"block-scoping",
+	"named-functions",
+	"optional-semicolons",
+	"trailing-commas",
+	"classes",
+	"comparisons",
+	"contracts",
+	"tuples",
+	"keyword-arguments",
+}
+
+// a keyword the language gained after the 2024 edition; while all of its
+// features are off the word is an ordinary identifier
+type gatedKeyword struct {
+	// the construct the keyword starts, for error messages
+	what     string
+	features []string
+}
+
+var gatedKeywords = map[token.TokenType]gatedKeyword{
+	token.MACRO:    {"macro", []string{"macros"}},
+	token.IMPORT:   {"import", []string{"modules"}},
+	token.EXPORT:   {"export", []string{"modules"}},
+	token.AS:       {"import alias", []string{"modules"}},
+	token.CONST:    {"const", []string{"constants"}},
+	token.STRUCT:   {"struct declaration", []string{"structs"}},
+	token.ENUM:     {"enum declaration", []string{"enums"}},
+	token.MATCH:    {"match expression", []string{"match"}},
+	token.THROW:    {"throw", []string{"exceptions"}},
+	token.TRY:      {"try expression", []string{"exceptions"}},
+	token.CATCH:    {"try expression", []string{"exceptions"}},
+	token.FINALLY:  {"try expression", []string{"exceptions"}},
+	token.DEFER:    {"defer", []string{"defer"}},
+	token.YIELD:    {"yield", []string{"generators"}},
+	token.FOR:      {"for loop", []string{"loops", "comprehensions"}},
+	token.IN:       {"in operator", []string{"ranges", "loops", "comprehensions"}},
+	token.CLASS:    {"class declaration", []string{"classes"}},
+	token.REQUIRES: {"requires clause", []string{"contracts"}},
+	token.ENSURES:  {"ensures clause", []string{"contracts"}},
+}
+
+// the features each edition enables
+var editions = map[string][]string{
+	"2024": {},
+	"2026": featureNames,
+}
+
+// Features is the set of optional features enabled for a file.
+type Features map[string]bool
+
+// EditionFeatures returns the features of edition, false for an unknown edition.
+func EditionFeatures(edition string) (Features, bool) {
+	names, ok := editions[edition]
+	if !ok {
+		return nil, false
+	}
+
+	features := Features{}
+	for _, name := range names {
+		features[name] = true
+	}
+	return features, true
+}
+
+// ApplyPragma returns features as changed by stmt: an edition replaces the
+// set, a feature is added to it.
+func ApplyPragma(features Features, stmt *ast.UseStatement) (Features, error) {
+	switch stmt.Kind.Value {
+	case "edition":
+		edition, ok := EditionFeatures(stmt.Value.Value)
+		if !ok {
+			return features, fmt.Errorf("unknown edition %q", stmt.Value.Value)
+		}
+		return edition, nil
+	case "feature":
+		if !isFeature(stmt.Value.Value) {
+			return features, fmt.Errorf("unknown feature %q", stmt.Value.Value)
+		}
+		added := Features{stmt.Value.Value: true}
+		for name := range features {
+			added[name] = true
+		}
+		return added, nil
+	default:
+		return features, fmt.Errorf("expect edition or feature after use, got %s", stmt.Kind.Value)
+	}
+}
+
+func isFeature(name string) bool {
+	for _, feature := range featureNames {
+		if feature == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetFeatures makes the parser start from features instead of the latest
+// edition, e.g. to carry the pragmas of earlier REPL lines over.
+func (p *Parser) SetFeatures(features Features) {
+	p.features = features
+	// the tokens read ahead were classified under the old features
+	p.curToken = p.keyword(p.curToken)
+	p.peekToken = p.keyword(p.peekToken)
+}
+
+// keyword turns a keyword whose features are all off into an identifier,
+// and back once one of them is on.
+func (p *Parser) keyword(tok token.Token) token.Token {
+	kind := token.LoopupIdentifier(tok.Literal)
+	gated, ok := gatedKeywords[kind]
+	if !ok || (tok.Type != kind && tok.Type != token.IDENT) {
+		return tok
+	}
+
+	tok.Type = token.IDENT
+	for _, feature := range gated.features {
+		if p.features[feature] {
+			tok.Type = kind
+		}
+	}
+	p.noteDemoted(tok)
+	return tok
+}
+
+func (p *Parser) noteDemoted(tok token.Token) {
+	if p.demoted == nil && tok.Type == token.IDENT && gatedKeywords[token.LoopupIdentifier(tok.Literal)].what != "" {
+		p.demoted = &tok
+		p.demotedInBrackets = p.nesting > 0
+	}
+}
+
+// explainKeyword replaces the errors of the statement from start by the
+// feature error of a keyword read as an identifier in it, as the statement
+// most likely meant the keyword. It reports whether it did.
+func (p *Parser) explainKeyword(start token.Token, errors int) bool {
+	if len(p.errors) == errors || p.demoted == nil {
+		return false
+	}
+	demoted := *p.demoted
+	if before(demoted, start) || before(p.peekToken, demoted) {
+		return false
+	}
+
+	kind := token.LoopupIdentifier(demoted.Literal)
+	gated := gatedKeywords[kind]
+	if kind == token.FOR && p.demotedInBrackets {
+		gated = gatedKeyword{"comprehension", []string{"comprehensions"}}
+	}
+	p.errors = append(p.errors[:errors], featureError(gated.features[0], gated.what))
+	return true
+}
+
+func before(a, b token.Token) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+}
+
+// Features returns the features in effect after the pragmas parsed so far.
+func (p *Parser) Features() Features {
+	return p.features
+}
+
+// require reports an error when feature is off; what names the construct using it.
+func (p *Parser) require(feature, what string) {
+	if p.features[feature] {
+		return
+	}
+	p.errors = append(p.errors, featureError(feature, what))
+}
+
+func featureError(feature, what string) string {
+	return fmt.Sprintf("%s requires feature %q; add `use feature %q;` or `use edition %q;`",
+		what, feature, feature, LatestEdition)
+}
@@ -0,0 +1,196 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/lexer"
+)
+
+func TestUseStatement(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{`use edition "2026"; let x = 1`, `use edition "2026";let x = 1;`},
+		{"use edition \"2024\"\nuse feature \"loops\"\nfor x in xs { x }", `use edition "2024";use feature "loops";for x in xs { x }`},
+		{`use feature "tuples"; (1, 2)`, `use feature "tuples";(1, 2)`},
+		{`let x = [1, 2]`, `let x = [1, 2];`},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+
+	program := New(lexer.New(`use feature "classes"`)).ParserProgram()
+	stmt, ok := program.Statements[0].(*ast.UseStatement)
+	if !ok || stmt.Kind.Value != "feature" || stmt.Value.Value != "classes" {
+		t.Errorf("wrong use statement: %+v", program.Statements[0])
+	}
+}
+
+func TestEditionGates(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{`use edition "2024"; for x in xs { x }`, "for loop requires feature \"loops\"; add `use feature \"loops\";` or `use edition \"2026\";`"},
+		{`use edition "2024"; [x for x in xs]`, "comprehension requires feature \"comprehensions\"; add `use feature \"comprehensions\";` or `use edition \"2026\";`"},
+		{`use edition "2024"; let n: int = 1`, "type annotation requires feature \"types\"; add `use feature \"types\";` or `use edition \"2026\";`"},
+		{`use edition "2024"; class C { }`, "class declaration requires feature \"classes\"; add `use feature \"classes\";` or `use edition \"2026\";`"},
+		{`use edition "2024"; fn(x) ensures result > 0 { x }`, "ensures clause requires feature \"contracts\"; add `use feature \"contracts\";` or `use edition \"2026\";`"},
+		{`use edition "2024"; (1, 2)`, "tuple requires feature \"tuples\"; add `use feature \"tuples\";` or `use edition \"2026\";`"},
+		{`use edition "2024"; fn() { return 1, 2 }`, "returning several values requires feature \"tuples\"; add `use feature \"tuples\";` or `use edition \"2026\";`"},
+		{`use edition "2024"; let (a, b) = f()`, "tuple unpacking requires feature \"tuples\"; add `use feature \"tuples\";` or `use edition \"2026\";`"},
+		{`use edition "2024"; f(x: 1)`, "keyword argument requires feature \"keyword-arguments\"; add `use feature \"keyword-arguments\";` or `use edition \"2026\";`"},
+		{`use edition "2030";`, `unknown edition "2030"`},
+		{`use feature "goto";`, `unknown feature "goto"`},
+		{`use feature "loops"; use edition "2024";`, "use edition must be the first pragma"},
+		{`let x = 1; use edition "2024";`, "use must come before any other statement"},
+		{`fn() { use feature "loops" }`, "use must come before any other statement"},
+		{`use something "x"`, "expect edition or feature after use, got something"},
+	}
+
+	for _, itm := range tests {
+		p := New(lexer.New(itm.input))
+		p.ParserProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != itm.expect {
+			t.Errorf("%s: expect error %q, got %v", itm.input, itm.expect, p.Errors())
+		}
+	}
+}
+
+func TestEveryFeatureGated(t *testing.T) {
+	tests := []struct {
+		input   string
+		what    string
+		feature string
+	}{
+		{`let m = macro(a) { a };`, "macro", "macros"},
+		{`quote(1 + 2);`, "quote", "macros"},
+		{`import "lib.mk";`, "import", "modules"},
+		{`export let x = 1;`, "export", "modules"},
+		{`const x = 1;`, "const", "constants"},
+		{`const (a, b) = t;`, "const", "constants"},
+		{`x = 1;`, "assignment", "assignment"},
+		{`x += 1;`, "assignment", "assignment"},
+		{`p.x;`, "member access", "member-access"},
+		{`struct P { x };`, "struct declaration", "structs"},
+		{`enum E { A };`, "enum declaration", "enums"},
+		{`match (x) { _ => 1 };`, "match expression", "match"},
+		{`throw "x";`, "throw", "exceptions"},
+		{`try { 1 } catch { 2 };`, "try expression", "exceptions"},
+		{`fn() { defer f(); };`, "defer", "defer"},
+		{`fn() { yield 1; };`, "yield", "generators"},
+		{`0..10;`, "range", "ranges"},
+		{`1 in xs;`, "in operator", "ranges"},
+		{`1 <= 2;`, "<= operator", "comparisons"},
+		{`1 >= 2;`, ">= operator", "comparisons"},
+		{`fn add(a, b) { a + b };`, "function name", "named-functions"},
+		{`let f = fn g() { 1 };`, "function name", "named-functions"},
+		{`f(1, 2,);`, "trailing comma", "trailing-commas"},
+		{`[1, 2,];`, "trailing comma", "trailing-commas"},
+		{`fn(a, b,) { a };`, "trailing comma", "trailing-commas"},
+	}
+
+	for _, itm := range tests {
+		p := New(lexer.New(`use edition "2024"; ` + itm.input))
+		p.ParserProgram()
+
+		expect := fmt.Sprintf("%s requires feature %q; add `use feature %q;` or `use edition %q;`", itm.what, itm.feature, itm.feature, LatestEdition)
+		if len(p.Errors()) == 0 || p.Errors()[0] != expect {
+			t.Errorf("%s: expect error %q, got %v", itm.input, expect, p.Errors())
+		}
+
+		p = New(lexer.New(fmt.Sprintf(`use edition "2024"; use feature %q; `, itm.feature) + itm.input))
+		p.ParserProgram()
+		for _, err := range p.Errors() {
+			if strings.Contains(err, fmt.Sprintf("feature %q", itm.feature)) {
+				t.Errorf("%s: still gated with the feature on: %s", itm.input, err)
+			}
+		}
+	}
+}
+
+func TestKeywordsAsIdentifiers(t *testing.T) {
+	input := `use edition "2024";
+let match = fn(a) { a };
+let class = 2;
+let for = [match(class), 3];
+let const = {"in": for};
+const["in"][0] + in(1)
+`
+
+	p := New(lexer.New(input))
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	expect := `use edition "2024";let match = fn(a)a;let class = 2;let for = [match(class), 3];let const = {in:for};(((const[in])[0]) + in(1))`
+	if program.String() != expect {
+		t.Errorf("expect %s, got %s", expect, program.String())
+	}
+
+	// a feature turned on later makes the word a keyword again
+	p = New(lexer.New(`use edition "2024"; use feature "match"; match (1) { _ => 2 }`))
+	program = p.ParserProgram()
+	CheckParserErrors(t, p)
+	if _, ok := program.Statements[2].(*ast.ExpressionStatement).Expression.(*ast.MatchExpression); !ok {
+		t.Errorf("expect a match expression, got %s", program.Statements[2])
+	}
+}
+
+func TestEditionBaselineSyntax(t *testing.T) {
+	input := `use edition "2024";
+let add = fn(a, b) {
+	a +
+	b
+};
+let h = {"a": [1, 2],};
+if (add(1, 2) > 2) { h["a"] } else { -1 };`
+
+	p := New(lexer.New(input))
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	// a line break after an operator continues the statement
+	expect := `use edition "2024";let add = fn(a, b)(a + b);let h = {a:[1, 2]};if(add(1, 2) > 2) (h[a])(-1)`
+	if program.String() != expect {
+		t.Errorf("expect %s, got %s", expect, program.String())
+	}
+
+	// a line break still ends a statement, which needs optional semicolons
+	for _, input := range []string{"let x = 1\n-1;", "let a = 5\nputs(a);"} {
+		p = New(lexer.New(`use edition "2024";` + input))
+		p.ParserProgram()
+
+		expect := "ending a statement at a line break requires feature \"optional-semicolons\"; add `use feature \"optional-semicolons\";` or `use edition \"2026\";`"
+		if len(p.Errors()) != 1 || p.Errors()[0] != expect {
+			t.Errorf("%q: expect error %q, got %v", input, expect, p.Errors())
+		}
+	}
+}
+
+func TestFeaturesCarryOver(t *testing.T) {
+	p := New(lexer.New(`use edition "2024"; use feature "constants";`))
+	p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	next := New(lexer.New(`const x = 1; x = 2;`))
+	next.SetFeatures(p.Features())
+	next.ParserProgram()
+
+	expect := "assignment requires feature \"assignment\"; add `use feature \"assignment\";` or `use edition \"2026\";`"
+	if len(next.Errors()) == 0 || next.Errors()[0] != expect {
+		t.Errorf("expect only the assignment to be gated, got %v", next.Errors())
+	}
+}
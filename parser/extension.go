@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"fmt"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/token"
+)
+
+// Extension API for embedders that want to add their own syntax without
+// forking the parser. Register tokens first, then the parse functions:
+//
+//	p := parser.New(lexer.New(input))
+//	p.RegisterToken("|>", "|>")
+//	p.RegisterInfixOperator("|>", parser.SUM)
+//	program := p.ParserProgram()
+//
+// Custom AST nodes are evaluated through evaluator.RegisterNode.
+
+// RegisterToken teaches the lexer a new keyword or operator. The lexer is
+// rewound and the parser re-primed, so it must be called before parsing.
+func (p *Parser) RegisterToken(literal string, tokType token.TokenType) {
+	p.lex.Register(literal, tokType)
+	p.lex.Reset()
+	p.nextToken()
+	p.nextToken()
+}
+
+// RegisterPrefix installs fn for expressions starting with tokType, replacing any built-in one.
+func (p *Parser) RegisterPrefix(tokType token.TokenType, fn PrefixParseFn) {
+	p.registerPrefix(tokType, fn)
+}
+
+// RegisterInfix installs fn for tokType in infix position. The token also
+// needs a precedence above LOWEST, see SetPrecedence.
+func (p *Parser) RegisterInfix(tokType token.TokenType, fn InfixParseFn, precedence int) {
+	p.registerInFix(tokType, fn)
+	p.SetPrecedence(tokType, precedence)
+}
+
+// RegisterInfixOperator parses tokType as a left associative binary
+// operator, producing an *ast.InFixExpression with the token literal as operator.
+func (p *Parser) RegisterInfixOperator(tokType token.TokenType, precedence int) {
+	p.RegisterInfix(tokType, p.parseInfixExpression, precedence)
+}
+
+// RegisterPrefixOperator parses tokType as a unary operator, producing an *ast.PrefixExpression.
+func (p *Parser) RegisterPrefixOperator(tokType token.TokenType) {
+	p.registerPrefix(tokType, p.parserPrefixExpression)
+}
+
+// SetPrecedence changes the binding power of tokType for this parser only.
+func (p *Parser) SetPrecedence(tokType token.TokenType, precedence int) {
+	p.precedences[tokType] = precedence
+}
+
+// helpers for parse functions written outside this package
+
+func (p *Parser) CurToken() token.Token {
+	return p.curToken
+}
+
+func (p *Parser) PeekToken() token.Token {
+	return p.peekToken
+}
+
+func (p *Parser) NextToken() {
+	p.nextToken()
+}
+
+func (p *Parser) PeekTokenIs(tok token.TokenType) bool {
+	return p.peekTokenIs(tok)
+}
+
+// ExpectPeek advances if the next token is t, otherwise it records an error.
+func (p *Parser) ExpectPeek(t token.TokenType) bool {
+	return p.expectPeek(t)
+}
+
+func (p *Parser) CurPrecedence() int {
+	return p.curPrecedence()
+}
+
+func (p *Parser) ParseExpression(precedence int) ast.Expression {
+	return p.parseExpression(precedence)
+}
+
+func (p *Parser) ParseBlockStatements() *ast.BlockStatements {
+	return p.parseBlockStatements()
+}
+
+// Errorf records a parse error.
+func (p *Parser) Errorf(format string, args ...interface{}) {
+	p.errors = append(p.errors, fmt.Sprintf(format, args...))
+}
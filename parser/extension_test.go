@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"testing"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/lexer"
+)
+
+func TestRegisterInfixOperator(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"a |> f", "(a |> f)"},
+		{"a + b |> f |> g", "(((a + b) |> f) |> g)"},
+		{"2 ** 3 * 4", "((2 ** 3) * 4)"},
+		{"a |> f == b", "((a |> f) == b)"},
+	}
+
+	for _, itm := range tests {
+		p := New(lexer.New(itm.input))
+		p.RegisterToken("|>", "|>")
+		p.RegisterToken("**", "**")
+		p.RegisterInfixOperator("|>", EQUALS+1)
+		p.RegisterInfixOperator("**", PREFIX)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+}
+
+func TestRegisterPrefix(t *testing.T) {
+	p := New(lexer.New("unless (x) { 1 }"))
+	p.RegisterToken("unless", "UNLESS")
+	p.RegisterPrefix("UNLESS", func() ast.Expression {
+		exp := &ast.IfExpression{Token: p.CurToken()}
+		if !p.ExpectPeek("(") {
+			return nil
+		}
+		p.NextToken()
+		exp.Confition = &ast.PrefixExpression{Token: p.CurToken(), Operator: "!", Right: p.ParseExpression(LOWEST)}
+		if !p.ExpectPeek(")") || !p.ExpectPeek("{") {
+			return nil
+		}
+		exp.Consequence = p.ParseBlockStatements()
+		return exp
+	})
+
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	if program.String() != "if(!x) 1" {
+		t.Errorf("expect %s, got %s", "if(!x) 1", program.String())
+	}
+}
+
+func TestPrecedenceIsPerParser(t *testing.T) {
+	p := New(lexer.New("1 + 2 * 3"))
+	p.SetPrecedence("+", PRODUCT+1)
+	if got := p.ParserProgram().String(); got != "((1 + 2) * 3)" {
+		t.Errorf("expect ((1 + 2) * 3), got %s", got)
+	}
+
+	p = New(lexer.New("1 + 2 * 3"))
+	if got := p.ParserProgram().String(); got != "(1 + (2 * 3))" {
+		t.Errorf("expect (1 + (2 * 3)), got %s", got)
+	}
+}
+
+func TestUnregisteredToken(t *testing.T) {
+	p := New(lexer.New("a |> b"))
+	p.ParserProgram()
+
+	if len(p.Errors()) == 0 {
+		t.Errorf("expect parse errors for an unregistered operator")
+	}
+}
@@ -202,20 +202,26 @@ func TestParsingHashLiteralsStringsKeys(t *testing.T) {
 		t.Errorf("hash.Pairs has wrong length.  got %d", len(hash.Pairs))
 	}
 
-	expect := map[string]int64{
-		"one":   1,
-		"two":   2,
-		"three": 3,
+	expect := []struct {
+		key string
+		val int64
+	}{
+		{"one", 1},
+		{"two", 2},
+		{"three", 3},
 	}
 
-	for key, val := range hash.Pairs {
-		literal, ok := key.(*ast.StringLiteral)
+	for i, pair := range hash.Pairs {
+		literal, ok := pair.Key.(*ast.StringLiteral)
 		if !ok {
-			t.Errorf("key is not ast.StringLiteranl, got %T", key)
+			t.Errorf("key is not ast.StringLiteranl, got %T", pair.Key)
+			continue
 		}
 
-		expectVal := expect[literal.String()]
-		testIntegerLiterals(t, val, expectVal)
+		if literal.String() != expect[i].key {
+			t.Errorf("expect key %s at %d, got %s", expect[i].key, i, literal.String())
+		}
+		testIntegerLiterals(t, pair.Value, expect[i].val)
 	}
 }
 
@@ -263,20 +269,20 @@ func TestParsingHashLiteralsWithExpressions(t *testing.T) {
 		},
 	}
 
-	for key, val := range hash.Pairs {
-		literal, ok := key.(*ast.StringLiteral)
+	for _, pair := range hash.Pairs {
+		literal, ok := pair.Key.(*ast.StringLiteral)
 		if !ok {
 
 			continue
 		}
 
-		testFunc, ok := tests[key.String()]
+		testFunc, ok := tests[pair.Key.String()]
 		if !ok {
 			t.Errorf("no test function for %s", literal.String())
 			continue
 		}
 
-		testFunc(val)
+		testFunc(pair.Value)
 	}
 }
 
@@ -788,3 +794,364 @@ func TestCallFunction(t *testing.T) {
 	testInfixExression(t, callExp.Arguments[1], 2, "*", 3)
 	testInfixExression(t, callExp.Arguments[2], 4, "+", 5)
 }
+
+func TestMacroLiteralParsing(t *testing.T) {
+	input := `macro(x, y) { x + y; }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("expect 1 statements, got %d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("expect expressionStatement, got %T", program.Statements[0])
+	}
+
+	macro, ok := stmt.Expression.(*ast.MacroLiteral)
+	if !ok {
+		t.Fatalf("expect macroLiteral, got %T", stmt.Expression)
+	}
+
+	if len(macro.Parameters) != 2 {
+		t.Fatalf("expect 2 parameters, got %d", len(macro.Parameters))
+	}
+
+	testingLiteralExpression(t, macro.Parameters[0], "x")
+	testingLiteralExpression(t, macro.Parameters[1], "y")
+
+	if len(macro.Body.Statements) != 1 {
+		t.Fatalf("expect 1 body statement, got %d", len(macro.Body.Statements))
+	}
+
+	bodyStmt, ok := macro.Body.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("expect expressionStatement body, got %T", macro.Body.Statements[0])
+	}
+
+	testInfixExression(t, bodyStmt.Expression, "x", "+", "y")
+}
+
+func TestParsingMemberExpression(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"strings.upper", "(strings.upper)"},
+		{"strings.upper(name)", "(strings.upper)(name)"},
+		{"a.b.c", "((a.b).c)"},
+		{"-a.b", "(-(a.b))"},
+		{"a.b[1] + 2", "(((a.b)[1]) + 2)"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+}
+
+func TestMatchExpression(t *testing.T) {
+	input := `match (s) {
+		Shape.Circle(r) => r * r,
+		Shape.Empty => { 0 }
+		_ => -1
+	}`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("expect expressionStatement, got %T", program.Statements[0])
+	}
+
+	match, ok := stmt.Expression.(*ast.MatchExpression)
+	if !ok {
+		t.Fatalf("expect matchExpression, got %T", stmt.Expression)
+	}
+
+	testIdentifier(t, match.Subject, "s")
+
+	tests := []struct {
+		pattern string
+		body    string
+	}{
+		{"(Shape.Circle)(r)", "(r * r)"},
+		{"(Shape.Empty)", "0"},
+		{"_", "(-1)"},
+	}
+
+	if len(match.Arms) != len(tests) {
+		t.Fatalf("expect %d arms, got %d", len(tests), len(match.Arms))
+	}
+
+	for i, itm := range tests {
+		if match.Arms[i].Pattern.String() != itm.pattern {
+			t.Errorf("expect pattern %s, got %s", itm.pattern, match.Arms[i].Pattern.String())
+		}
+		if match.Arms[i].Body.String() != itm.body {
+			t.Errorf("expect body %s, got %s", itm.body, match.Arms[i].Body.String())
+		}
+	}
+
+	if _, ok := match.Arms[1].Body.(*ast.BlockStatements); !ok {
+		t.Errorf("expect block body, got %T", match.Arms[1].Body)
+	}
+}
+
+func TestTryExpression(t *testing.T) {
+	tests := []struct {
+		input      string
+		expect     string
+		hasParam   bool
+		hasCatch   bool
+		hasFinally bool
+	}{
+		{`try { f(); } catch (e) { e; }`, "try f() catch(e) e", true, true, false},
+		{`try { f(); } catch { 1; }`, "try f() catch 1", false, true, false},
+		{`try { f(); } finally { g(); }`, "try f() finally g()", false, false, true},
+		{`try { f(); } catch (e) { 1; } finally { g(); }`, "try f() catch(e) 1 finally g()", true, true, true},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		exp, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.TryExpression)
+		if !ok {
+			t.Fatalf("expect tryExpression, got %T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+		}
+
+		if (exp.Parameter != nil) != itm.hasParam || (exp.Catch != nil) != itm.hasCatch || (exp.Finally != nil) != itm.hasFinally {
+			t.Errorf("%s: wrong clauses, got %+v", itm.input, exp)
+		}
+
+		if exp.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, exp.String())
+		}
+	}
+
+	p := New(lexer.New(`try { f(); }`))
+	p.ParserProgram()
+	if len(p.Errors()) == 0 || p.Errors()[0] != "try without catch or finally" {
+		t.Errorf("expect try without catch or finally error, got %v", p.Errors())
+	}
+}
+
+func TestThrowStatement(t *testing.T) {
+	input := `throw "boom";`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*ast.ThrowStatement)
+	if !ok {
+		t.Fatalf("expect throwStatement, got %T", program.Statements[0])
+	}
+
+	if stmt.String() != `throw boom;` {
+		t.Errorf("expect throw boom;, got %s", stmt.String())
+	}
+}
+
+func TestYieldMarksGenerator(t *testing.T) {
+	tests := []struct {
+		input       string
+		isGenerator bool
+	}{
+		{"fn() { yield 1; }", true},
+		{"fn() { if (true) { yield 1; } }", true},
+		{"fn() { 1 }", false},
+		{"fn() { let f = fn() { yield 1; }; f }", false},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		function := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.FunctionLiteral)
+		if function.IsGenerator != itm.isGenerator {
+			t.Errorf("%s: expect IsGenerator %t, got %t", itm.input, itm.isGenerator, function.IsGenerator)
+		}
+	}
+
+	p := New(lexer.New("yield 1;"))
+	p.ParserProgram()
+	if len(p.Errors()) == 0 || p.Errors()[0] != "yield outside function" {
+		t.Errorf("expect yield outside function error, got %v", p.Errors())
+	}
+}
+
+func TestHashLiteralKeepsSourceOrder(t *testing.T) {
+	input := `{"b": 1, "a": 2, 3: x, true: fn(y) { y }}`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	expect := `{b:1, a:2, 3:x, true:fn(y)y}`
+	if program.String() != expect {
+		t.Errorf("expect %s, got %s", expect, program.String())
+	}
+}
+
+func TestRangeExpression(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"1..10", "(1..10)"},
+		{"0..n step 2", "(0..n step 2)"},
+		{"0..n + 1", "(0..(n + 1))"},
+		{"x in 0..10", "(x in (0..10))"},
+		{"a..b step c * 2 == r", "((a..b step (c * 2)) == r)"},
+		{"let step = 1; 0..step", "let step = 1;(0..step)"},
+		{"x in xs == true", "((x in xs) == true)"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+}
+
+func TestComprehension(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"[x * 2 for x in xs]", "[(x * 2) for x in xs]"},
+		{"[x for x in xs if x > 1]", "[x for x in xs if (x > 1)]"},
+		{"[x for x in xs if x > 1 if x < 5]", "[x for x in xs if (x > 1) if (x < 5)]"},
+		{"[[x, y] for x in xs for y in 0..x]", "[[x, y] for x in xs for y in (0..x)]"},
+		{"[i for i, x in xs if x]", "[i for i, x in xs if x]"},
+		{"{k: v * 2 for k, v in h}", "{k:(v * 2) for k, v in h}"},
+		{"{x: true for x in xs if x != 0}", "{x:true for x in xs if (x != 0)}"},
+		{"[1, 2, 3]", "[1, 2, 3]"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+}
+
+func TestTypeAnnotations(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"fn(x: int, name: string) -> array { [x, name] }", "fn(x: int, name: string) -> array [x, name]"},
+		{"fn(x, y: int) { x }", "fn(x, y: int)x"},
+		{"fn(f: fn) -> fn { f }", "fn(f: fn) -> fn f"},
+		{"fn() -> Point { p }", "fn() -> Point p"},
+		{"let n: int = 5;", "let n: int = 5;"},
+		{"const name: string = \"monkey\";", "const name: string = monkey;"},
+		{"fn(x) { x }", "fn(x)x"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+
+	program := New(lexer.New("fn(x: int, y) -> bool { true }")).ParserProgram()
+	fn := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.FunctionLiteral)
+	if len(fn.ParameterTypes) != 2 || fn.ParameterTypes[0].Value != "int" || fn.ParameterTypes[1] != nil {
+		t.Errorf("wrong parameter types: %+v", fn.ParameterTypes)
+	}
+	if fn.ReturnType == nil || fn.ReturnType.Value != "bool" {
+		t.Errorf("wrong return type: %+v", fn.ReturnType)
+	}
+}
+
+func TestContracts(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"fn(x) requires x > 0 ensures result >= 0 { x }", "fn(x) requires (x > 0)  ensures (result >= 0) x"},
+		{"fn(x, y) -> int requires x >= 0 requires y <= 10 { x }", "fn(x, y) -> int  requires (x >= 0)  requires (y <= 10) x"},
+		{"fn sq(x) ensures result >= x { x * x }", "fn sq(x) ensures (result >= x) (x * x)"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %q, got %q", itm.expect, program.String())
+		}
+	}
+
+	program := New(lexer.New("fn(x) requires x > 0 ensures result > 0 ensures result < 10 { x }")).ParserProgram()
+	fn := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.FunctionLiteral)
+	if len(fn.Requires) != 1 || len(fn.Ensures) != 2 {
+		t.Errorf("wrong clauses: requires %d, ensures %d", len(fn.Requires), len(fn.Ensures))
+	}
+
+	p := New(lexer.New("fn gen(n) requires n > 0 ensures result { yield n }"))
+	p.ParserProgram()
+	if len(p.Errors()) != 1 || p.Errors()[0] != "ensures clause on a generator function" {
+		t.Errorf("expect ensures on a generator to be rejected, got %v", p.Errors())
+	}
+}
+
+func TestTypeAnnotationErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"fn(x: 1) { x }", "expect next token to be IDENT, got INT instead"},
+		{"let x: = 1;", "expect next token to be IDENT, got = instead"},
+		{"macro(x: int) { x }", "unexpected type annotation on x"},
+	}
+
+	for _, itm := range tests {
+		p := New(lexer.New(itm.input))
+		p.ParserProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != itm.expect {
+			t.Errorf("%s: expect error %q, got %v", itm.input, itm.expect, p.Errors())
+		}
+	}
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"com.language/monkey/ast"
 	"com.language/monkey/lexer"
@@ -10,8 +11,8 @@ import (
 )
 
 type (
-	prefixParseFn func() ast.Expression
-	infixParseFn  func(ast.Expression) ast.Expression
+	PrefixParseFn func() ast.Expression
+	InfixParseFn  func(ast.Expression) ast.Expression
 )
 
 var precedences = map[token.TokenType]int{
@@ -19,12 +20,23 @@ var precedences = map[token.TokenType]int{
 	token.NOTEQUAL: EQUALS,
 	token.LESS:     LESSGREATER,
 	token.GREAT:    LESSGREATER,
+	token.LEQ:      LESSGREATER,
+	token.GEQ:      LESSGREATER,
 	token.PLUS:     SUM,
 	token.MINUS:    SUM,
 	token.SLASH:    PRODUCT,
 	token.ASTERISK: PRODUCT,
 	token.LPAREN:   CALL,
 	token.LBRACKET: INDEX,
+	token.DOT:      INDEX,
+	token.ASSIGN:   ASSIGN,
+
+	token.PLUS_ASSIGN:     ASSIGN,
+	token.MINUS_ASSIGN:    ASSIGN,
+	token.ASTERISK_ASSIGN: ASSIGN,
+	token.SLASH_ASSIGN:    ASSIGN,
+	token.DOTDOT:          RANGE,
+	token.IN:              LESSGREATER,
 }
 
 type Parser struct {
@@ -36,19 +48,46 @@ type Parser struct {
 	errors []string
 
 	// parser detail
-	prefixParseFns map[token.TokenType]prefixParseFn
-	infixParseFns  map[token.TokenType]infixParseFn
+	prefixParseFns map[token.TokenType]PrefixParseFn
+	infixParseFns  map[token.TokenType]InfixParseFn
+	precedences    map[token.TokenType]int
+
+	// names declared so far, innermost scope last. constants map to their
+	// declaring identifier, everything else to nil
+	scopes []map[string]*ast.Identifier
+
+	// function literals being parsed, innermost last
+	functions []*ast.FunctionLiteral
+
+	// open brackets around the current position; inside them line
+	// breaks do not end a statement
+	nesting int
+
+	// the first keyword of the current statement read as an identifier
+	// because its features are off, and whether it was inside brackets
+	demoted           *token.Token
+	demotedInBrackets bool
+
+	// optional syntax enabled by the edition and `use` pragmas
+	features Features
 }
 
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
 		lex:    l,
 		errors: []string{},
+		scopes: []map[string]*ast.Identifier{{}},
 	}
+	p.features, _ = EditionFeatures(LatestEdition)
 	p.nextToken()
 	p.nextToken()
 
-	p.prefixParseFns = make(map[token.TokenType]prefixParseFn)
+	p.precedences = make(map[token.TokenType]int, len(precedences))
+	for tokType, precedence := range precedences {
+		p.precedences[tokType] = precedence
+	}
+
+	p.prefixParseFns = make(map[token.TokenType]PrefixParseFn)
 	p.registerPrefix(token.IDENT, p.parseIdentifier)
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
 	p.registerPrefix(token.BANG, p.parserPrefixExpression)
@@ -61,20 +100,34 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.STRING, p.parseStringLiteral)
 	p.registerPrefix(token.LBRACKET, p.parseArrayLiteral)
 	p.registerPrefix(token.LBRACE, p.parseHashLiteral)
+	p.registerPrefix(token.MACRO, p.parseMacroLiteral)
+	p.registerPrefix(token.MATCH, p.parseMatchExpression)
+	p.registerPrefix(token.TRY, p.parseTryExpression)
+	p.registerPrefix(token.YIELD, p.parseYieldExpression)
 
 	// infix parser register
-	p.infixParseFns = make(map[token.TokenType]infixParseFn)
+	p.infixParseFns = make(map[token.TokenType]InfixParseFn)
 	p.registerInFix(token.PLUS, p.parseInfixExpression)
 	p.registerInFix(token.MINUS, p.parseInfixExpression)
 	p.registerInFix(token.SLASH, p.parseInfixExpression)
 	p.registerInFix(token.ASTERISK, p.parseInfixExpression)
 	p.registerInFix(token.LESS, p.parseInfixExpression)
 	p.registerInFix(token.GREAT, p.parseInfixExpression)
+	p.registerInFix(token.LEQ, p.parseInfixExpression)
+	p.registerInFix(token.GEQ, p.parseInfixExpression)
 	p.registerInFix(token.EQUAL, p.parseInfixExpression)
 	p.registerInFix(token.NOTEQUAL, p.parseInfixExpression)
 	p.registerInFix(token.GREAT, p.parseInfixExpression)
 	p.registerInFix(token.LPAREN, p.parseCallExpression)
 	p.registerInFix(token.LBRACKET, p.parseIndexExpression)
+	p.registerInFix(token.DOT, p.parseMemberExpression)
+	p.registerInFix(token.ASSIGN, p.parseAssignExpression)
+	p.registerInFix(token.PLUS_ASSIGN, p.parseAssignExpression)
+	p.registerInFix(token.MINUS_ASSIGN, p.parseAssignExpression)
+	p.registerInFix(token.ASTERISK_ASSIGN, p.parseAssignExpression)
+	p.registerInFix(token.SLASH_ASSIGN, p.parseAssignExpression)
+	p.registerInFix(token.DOTDOT, p.parseRangeExpression)
+	p.registerInFix(token.IN, p.parseInfixExpression)
 
 	return p
 }
@@ -83,7 +136,9 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 	hash := &ast.HashLiteral{
 		Token: p.curToken,
 	}
-	hash.Pairs = make(map[ast.Expression]ast.Expression)
+
+	p.nest()
+	defer p.unnest()
 
 	for !p.peekTokenIs(token.RBRACE) {
 		p.nextToken()
@@ -96,7 +151,11 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 		p.nextToken()
 		value := p.parseExpression(LOWEST)
 
-		hash.Pairs[key] = value
+		if len(hash.Pairs) == 0 && p.peekTokenIs(token.FOR) {
+			return p.parseHashComprehension(hash.Token, key, value)
+		}
+
+		hash.Pairs = append(hash.Pairs, &ast.HashLiteralPair{Key: key, Value: value})
 
 		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
 			return nil
@@ -116,6 +175,9 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 		Left:  left,
 	}
 
+	p.nest()
+	defer p.unnest()
+
 	p.nextToken()
 	exp.Index = p.parseExpression(LOWEST)
 
@@ -126,13 +188,145 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	return exp
 }
 
+func (p *Parser) parseMemberExpression(left ast.Expression) ast.Expression {
+	p.require("member-access", "member access")
+	exp := &ast.MemberExpression{
+		Token:  p.curToken,
+		Object: left,
+	}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+
+	exp.Property = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	return exp
+}
+
+func (p *Parser) parseAssignExpression(left ast.Expression) ast.Expression {
+	tok := p.curToken
+	// "+=" -> "+", "=" -> ""
+	operator := strings.TrimSuffix(tok.Literal, "=")
+	p.require("assignment", "assignment")
+
+	// the prefix parser already reported why left is missing
+	if left == nil {
+		return nil
+	}
+
+	switch left := left.(type) {
+	case *ast.Identifier:
+		exp := &ast.AssignExpression{Token: tok, Name: left, Operator: operator}
+
+		if decl := p.lookupConstant(left.Value); decl != nil {
+			p.errors = append(p.errors, ConstantError("assign to", decl))
+		}
+
+		p.nextToken()
+		exp.Value = p.parseExpression(LOWEST)
+		return exp
+	case *ast.MemberExpression:
+		exp := &ast.MemberAssignExpression{Token: tok, Target: left, Operator: operator}
+
+		p.nextToken()
+		exp.Value = p.parseExpression(LOWEST)
+		return exp
+	default:
+		p.errors = append(p.errors, fmt.Sprintf("cannot assign to %s", left.String()))
+		return nil
+	}
+}
+
+func (p *Parser) parseRangeExpression(left ast.Expression) ast.Expression {
+	p.require("ranges", "range")
+	exp := &ast.RangeExpression{
+		Token: p.curToken,
+		Start: left,
+	}
+
+	p.nextToken()
+	exp.End = p.parseExpression(RANGE)
+
+	// step is only a keyword right after a range
+	if p.peekTokenIs(token.IDENT) && p.peekToken.Literal == "step" {
+		p.nextToken()
+		p.nextToken()
+		exp.Step = p.parseExpression(RANGE)
+	}
+
+	return exp
+}
+
 func (p *Parser) parseArrayLiteral() ast.Expression {
 	array := &ast.ArrayLiteral{Token: p.curToken}
-	array.Elements = p.parseExpressionList(token.RBRACKET)
+
+	p.nest()
+	defer p.unnest()
+
+	if p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+		array.Elements = []ast.Expression{}
+		return array
+	}
+
+	p.nextToken()
+	first := p.parseExpression(LOWEST)
+
+	if p.peekTokenIs(token.FOR) {
+		exp := &ast.ArrayComprehension{Token: array.Token, Element: first}
+		exp.Clauses = p.parseComprehensionClauses()
+		if exp.Clauses == nil || !p.expectPeek(token.RBRACKET) {
+			return nil
+		}
+		return exp
+	}
+
+	array.Elements = p.parseExpressionListAfter(first, token.RBRACKET)
 
 	return array
 }
 
+func (p *Parser) parseHashComprehension(tok token.Token, key, value ast.Expression) ast.Expression {
+	exp := &ast.HashComprehension{Token: tok, Key: key, Value: value}
+
+	exp.Clauses = p.parseComprehensionClauses()
+	if exp.Clauses == nil || !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	return exp
+}
+
+// for x in xs if x > 1 for y in ys ...
+func (p *Parser) parseComprehensionClauses() []*ast.ComprehensionClause {
+	p.require("comprehensions", "comprehension")
+	clauses := []*ast.ComprehensionClause{}
+
+	for p.peekTokenIs(token.FOR) {
+		p.nextToken()
+		clause := &ast.ComprehensionClause{Token: p.curToken}
+
+		clause.Variables = p.parseLoopVariables()
+		if clause.Variables == nil {
+			return nil
+		}
+
+		p.nextToken()
+		clause.Iterable = p.parseExpression(LOWEST)
+
+		for p.peekTokenIs(token.IF) {
+			p.nextToken()
+			p.nextToken()
+			clause.Conditions = append(clause.Conditions, p.parseExpression(LOWEST))
+		}
+
+		clauses = append(clauses, clause)
+	}
+
+	return clauses
+}
+
 // 解析标识符 表达式
 func (p *Parser) parseIdentifier() ast.Expression {
 	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
@@ -167,9 +361,28 @@ func (p *Parser) parseBooleanExpression() ast.Expression {
 }
 
 func (p *Parser) parseGroupExpression() ast.Expression {
+	p.nest()
+	defer p.unnest()
+
+	tok := p.curToken
+	// () is the empty tuple
+	if p.peekTokenIs(token.RPAREN) {
+		p.require("tuples", "tuple")
+		p.nextToken()
+		return &ast.TupleLiteral{Token: tok, Elements: []ast.Expression{}}
+	}
+
 	p.nextToken()
 
 	exp := p.parseExpression(LOWEST)
+	if p.peekTokenIs(token.COMMA) {
+		p.require("tuples", "tuple")
+		elements := p.parseExpressionListAfter(exp, token.RPAREN)
+		if elements == nil {
+			return nil
+		}
+		return &ast.TupleLiteral{Token: tok, Elements: elements}
+	}
 	if !p.expectPeek(token.RPAREN) {
 		return nil
 	}
@@ -185,7 +398,9 @@ func (p *Parser) parserIfExpression() ast.Expression {
 	}
 	p.nextToken()
 
+	p.nest()
 	expression.Confition = p.parseExpression(LOWEST)
+	p.unnest()
 
 	if !p.expectPeek(token.RPAREN) {
 		return nil
@@ -195,7 +410,7 @@ func (p *Parser) parserIfExpression() ast.Expression {
 		return nil
 	}
 
-	expression.Consequence = p.parseBlockStatements()
+	expression.Consequence = p.parseScopedBlock()
 
 	if p.peekTokenIs(token.ELSE) {
 		p.nextToken()
@@ -203,7 +418,7 @@ func (p *Parser) parserIfExpression() ast.Expression {
 			return nil
 		}
 
-		expression.Alternative = p.parseBlockStatements()
+		expression.Alternative = p.parseScopedBlock()
 	}
 
 	return expression
@@ -213,6 +428,91 @@ func (p *Parser) parserFunctionExpression() ast.Expression {
 	exp := &ast.FunctionLiteral{
 		Token: p.curToken,
 	}
+	if p.peekTokenIs(token.IDENT) {
+		p.require("named-functions", "function name")
+		p.nextToken()
+		exp.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+
+	return p.parseFunctionRest(exp)
+}
+
+// parseFunctionRest parses parameters, return type and body, starting just before the '('.
+func (p *Parser) parseFunctionRest(exp *ast.FunctionLiteral) ast.Expression {
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	exp.Parameters, exp.ParameterTypes = p.parseTypedParameters()
+
+	if p.peekTokenIs(token.ARROW) {
+		p.nextToken()
+		exp.ReturnType = p.parseTypeAnnotation()
+		if exp.ReturnType == nil {
+			return nil
+		}
+	}
+
+	// requires <expr> ensures <expr>, any number of each
+	p.enterScope(exp.Parameters)
+	for p.peekTokenIs(token.REQUIRES) || p.peekTokenIs(token.ENSURES) {
+		p.nextToken()
+		clause := p.curToken.Type
+		p.require("contracts", p.curToken.Literal+" clause")
+		p.nextToken()
+		condition := p.parseExpression(LOWEST)
+		if condition == nil {
+			p.leaveScope()
+			return nil
+		}
+		if clause == token.REQUIRES {
+			exp.Requires = append(exp.Requires, condition)
+		} else {
+			exp.Ensures = append(exp.Ensures, condition)
+		}
+	}
+	p.leaveScope()
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	p.enterScope(exp.Parameters)
+	p.functions = append(p.functions, exp)
+	exp.Body = p.parseBlockStatements()
+	p.functions = p.functions[:len(p.functions)-1]
+	p.leaveScope()
+
+	// a generator has no single result to check
+	if exp.IsGenerator && len(exp.Ensures) > 0 {
+		p.errors = append(p.errors, "ensures clause on a generator function")
+	}
+
+	return exp
+}
+
+func (p *Parser) parseYieldExpression() ast.Expression {
+	p.require("generators", "yield")
+	exp := &ast.YieldExpression{
+		Token: p.curToken,
+	}
+
+	if len(p.functions) == 0 {
+		p.errors = append(p.errors, "yield outside function")
+		return nil
+	}
+	p.functions[len(p.functions)-1].IsGenerator = true
+
+	p.nextToken()
+	exp.Value = p.parseExpression(LOWEST)
+
+	return exp
+}
+
+func (p *Parser) parseMacroLiteral() ast.Expression {
+	p.require("macros", "macro")
+	exp := &ast.MacroLiteral{
+		Token: p.curToken,
+	}
 	if !p.expectPeek(token.LPAREN) {
 		return nil
 	}
@@ -222,7 +522,115 @@ func (p *Parser) parserFunctionExpression() ast.Expression {
 		return nil
 	}
 
+	p.enterScope(exp.Parameters)
 	exp.Body = p.parseBlockStatements()
+	p.leaveScope()
+
+	return exp
+}
+
+func (p *Parser) parseMatchExpression() ast.Expression {
+	p.require("match", "match expression")
+	exp := &ast.MatchExpression{
+		Token: p.curToken,
+	}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	p.nextToken()
+	exp.Subject = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		arm := &ast.MatchArm{}
+		arm.Pattern = p.parseExpression(LOWEST)
+
+		if !p.expectPeek(token.FATARROW) {
+			return nil
+		}
+
+		// a brace after => always opens a block, wrap hash literals in parentheses
+		block := p.peekTokenIs(token.LBRACE)
+		p.nextToken()
+		if block {
+			arm.Body = p.parseBlockStatements()
+		} else {
+			arm.Body = p.parseExpression(LOWEST)
+		}
+		exp.Arms = append(exp.Arms, arm)
+
+		if p.peekTokenIs(token.COMMA) {
+			p.nextToken()
+		} else if !block && !p.peekTokenIs(token.RBRACE) {
+			p.peekError(token.COMMA)
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	return exp
+}
+
+func (p *Parser) parseTryExpression() ast.Expression {
+	p.require("exceptions", "try expression")
+	exp := &ast.TryExpression{
+		Token: p.curToken,
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+	exp.Block = p.parseScopedBlock()
+
+	if p.peekTokenIs(token.CATCH) {
+		p.nextToken()
+
+		if p.peekTokenIs(token.LPAREN) {
+			p.nextToken()
+			if !p.expectPeek(token.IDENT) {
+				return nil
+			}
+			exp.Parameter = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			if !p.expectPeek(token.RPAREN) {
+				return nil
+			}
+		}
+
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+		p.enterScope(nil)
+		if exp.Parameter != nil {
+			p.declare(exp.Parameter, false)
+		}
+		exp.Catch = p.parseBlockStatements()
+		p.leaveScope()
+	}
+
+	if p.peekTokenIs(token.FINALLY) {
+		p.nextToken()
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+		exp.Finally = p.parseScopedBlock()
+	}
+
+	if exp.Catch == nil && exp.Finally == nil {
+		p.errors = append(p.errors, "try without catch or finally")
+		return nil
+	}
 
 	return exp
 }
@@ -234,12 +642,15 @@ func (p *Parser) parseBlockStatements() *ast.BlockStatements {
 	}
 	block.Statements = []ast.Statement{}
 
+	// statements inside a block end at line breaks even when the block is an argument
+	nesting := p.nesting
+	p.nesting = 0
+	defer func() { p.nesting = nesting }()
+
 	p.nextToken()
 
 	for !p.curTokenIs(token.RBRACE) && !p.curTokenIs(token.EOF) {
-		stmt := p.parseStatement()
-
-		if stmt != nil {
+		if stmt := p.parseTerminatedStatement(); stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
 		p.nextToken()
@@ -248,36 +659,84 @@ func (p *Parser) parseBlockStatements() *ast.BlockStatements {
 	return block
 }
 
+// parseScopedBlock parses a block whose declarations end with it.
+func (p *Parser) parseScopedBlock() *ast.BlockStatements {
+	p.enterScope(nil)
+	defer p.leaveScope()
+
+	return p.parseBlockStatements()
+}
+
 func (p *Parser) parseFunctionParameters() []*ast.Identifier {
-	idts := []*ast.Identifier{}
+	idts, types := p.parseTypedParameters()
 
-	if p.peekTokenIs(token.RPAREN) {
-		p.nextToken()
-		return idts
+	for i, typ := range types {
+		if typ != nil {
+			p.errors = append(p.errors, fmt.Sprintf("unexpected type annotation on %s", idts[i].Value))
+		}
 	}
 
-	p.nextToken()
-
-	idt := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	return idts
+}
 
-	idts = append(idts, idt)
+// x: int, y -- the types slice is parallel to the names, nil where unannotated
+func (p *Parser) parseTypedParameters() ([]*ast.Identifier, []*ast.Identifier) {
+	idts := []*ast.Identifier{}
+	types := []*ast.Identifier{}
 
-	for p.peekTokenIs(token.COMMA) {
+	if p.peekTokenIs(token.RPAREN) {
 		p.nextToken()
+		return idts, types
+	}
+
+	for {
 		p.nextToken()
-		idt = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		idts = append(idts, &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal})
+
+		var typ *ast.Identifier
+		if p.peekTokenIs(token.COLON) {
+			p.nextToken()
+			if typ = p.parseTypeAnnotation(); typ == nil {
+				return nil, nil
+			}
+		}
+		types = append(types, typ)
 
-		idts = append(idts, idt)
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
+		// trailing comma
+		if p.peekTokenIs(token.RPAREN) {
+			p.require("trailing-commas", "trailing comma")
+			break
+		}
 	}
 
 	if !p.expectPeek(token.RPAREN) {
+		return nil, nil
+	}
+
+	return idts, types
+}
+
+// parseTypeAnnotation reads the type name following a ':' or '->'.
+func (p *Parser) parseTypeAnnotation() *ast.Identifier {
+	p.require("types", "type annotation")
+	// fn is a keyword but also names the function type
+	if p.peekTokenIs(token.FUNCTION) {
+		p.nextToken()
+	} else if !p.expectPeek(token.IDENT) {
 		return nil
 	}
 
-	return idts
+	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 }
 
 func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
+	if ident, ok := function.(*ast.Identifier); ok && ident.Value == "quote" {
+		p.require("macros", "quote")
+	}
 
 	exp := &ast.CallExpression{Token: p.curToken, Function: function}
 
@@ -287,29 +746,68 @@ func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
 }
 
 func (p *Parser) parseCallArguments() []ast.Expression {
-	args := []ast.Expression{}
+	p.nest()
+	defer p.unnest()
 
+	args := []ast.Expression{}
 	if p.peekTokenIs(token.RPAREN) {
 		p.nextToken()
 		return args
 	}
 
 	p.nextToken()
-	args = append(args, p.parseExpression(LOWEST))
-
+	args = append(args, p.parseCallArgument())
 	for p.peekTokenIs(token.COMMA) {
 		p.nextToken()
+		// trailing comma
+		if p.peekTokenIs(token.RPAREN) {
+			p.require("trailing-commas", "trailing comma")
+			break
+		}
 		p.nextToken()
-		args = append(args, p.parseExpression(LOWEST))
+		args = append(args, p.parseCallArgument())
 	}
 
 	if !p.expectPeek(token.RPAREN) {
 		return nil
 	}
 
+	// keyword arguments come last and name each parameter at most once
+	names := map[string]bool{}
+	for _, arg := range args {
+		keyword, ok := arg.(*ast.KeywordArgument)
+		if !ok {
+			if len(names) > 0 {
+				p.errors = append(p.errors, "positional argument after keyword argument")
+				return nil
+			}
+			continue
+		}
+		if names[keyword.Name.Value] {
+			p.errors = append(p.errors, fmt.Sprintf("duplicate keyword argument %s", keyword.Name.Value))
+			return nil
+		}
+		names[keyword.Name.Value] = true
+	}
+
 	return args
 }
 
+// parseCallArgument parses `name: value` as a keyword argument and anything else as an expression.
+func (p *Parser) parseCallArgument() ast.Expression {
+	if !p.curTokenIs(token.IDENT) || !p.peekTokenIs(token.COLON) {
+		return p.parseExpression(LOWEST)
+	}
+
+	p.require("keyword-arguments", "keyword argument")
+	arg := &ast.KeywordArgument{Token: p.curToken, Name: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}}
+	p.nextToken()
+	p.nextToken()
+	arg.Value = p.parseExpression(LOWEST)
+
+	return arg
+}
+
 func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
 	expresList := []ast.Expression{}
 
@@ -319,10 +817,21 @@ func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
 	}
 
 	p.nextToken()
-	expresList = append(expresList, p.parseExpression(LOWEST))
+
+	return p.parseExpressionListAfter(p.parseExpression(LOWEST), end)
+}
+
+// parseExpressionListAfter finishes a list whose first element is already parsed.
+func (p *Parser) parseExpressionListAfter(first ast.Expression, end token.TokenType) []ast.Expression {
+	expresList := []ast.Expression{first}
 
 	for p.peekTokenIs(token.COMMA) {
 		p.nextToken()
+		// trailing comma
+		if p.peekTokenIs(end) {
+			p.require("trailing-commas", "trailing comma")
+			break
+		}
 		p.nextToken()
 		expresList = append(expresList, p.parseExpression(LOWEST))
 	}
@@ -354,6 +863,13 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 		Left:     left,
 	}
 
+	switch expression.Operator {
+	case "in":
+		p.require("ranges", "in operator")
+	case "<=", ">=":
+		p.require("comparisons", expression.Operator+" operator")
+	}
+
 	precedence := p.curPrecedence()
 	p.nextToken()
 	expression.Right = p.parseExpression(precedence)
@@ -363,17 +879,30 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 
 func (p *Parser) nextToken() {
 	p.curToken = p.peekToken
-	p.peekToken = p.lex.NextToken()
+	p.peekToken = p.keyword(p.lex.NextToken())
 }
 
 func (p *Parser) ParserProgram() *ast.Program {
 	program := &ast.Program{}
 	program.Statements = []ast.Statement{}
 
-	for p.curToken.Type != token.EOF {
-		stmt := p.parseStatement()
+	// pragmas come before everything else, an edition first of all
+	for first := true; p.curTokenIs(token.USE); first = false {
+		if stmt := p.parseUseStatement(); stmt != nil {
+			if stmt.Kind.Value == "edition" && !first {
+				p.errors = append(p.errors, "use edition must be the first pragma")
+			} else if features, err := ApplyPragma(p.features, stmt); err != nil {
+				p.errors = append(p.errors, err.Error())
+			} else {
+				p.SetFeatures(features)
+				program.Statements = append(program.Statements, stmt)
+			}
+		}
+		p.nextToken()
+	}
 
-		if stmt != nil {
+	for p.curToken.Type != token.EOF {
+		if stmt := p.parseTerminatedStatement(); stmt != nil {
 			program.Statements = append(program.Statements, stmt)
 		}
 
@@ -383,22 +912,190 @@ func (p *Parser) ParserProgram() *ast.Program {
 	return program
 }
 
-func (p *Parser) parseStatement() ast.Statement {
+// parseTerminatedStatement parses a statement and checks what follows it.
+func (p *Parser) parseTerminatedStatement() ast.Statement {
+	errors := len(p.errors)
+	start := p.curToken
+	outer, outerInBrackets := p.demoted, p.demotedInBrackets
+	p.demoted = nil
+	p.noteDemoted(p.curToken)
+	p.noteDemoted(p.peekToken)
+
+	stmt := p.parseStatement()
+	if stmt != nil && len(p.errors) == errors {
+		p.expectStatementEnd()
+	}
+	if p.explainKeyword(start, errors) {
+		p.skipStatement()
+		stmt = nil
+	}
+
+	if outer != nil {
+		p.demoted, p.demotedInBrackets = outer, outerInBrackets
+	}
+	return stmt
+}
+
+// skipStatement moves to the last token of a statement that failed to parse.
+func (p *Parser) skipStatement() {
+	depth := 0
+	for !p.peekTokenIs(token.EOF) {
+		if depth <= 0 && (p.peekTokenIs(token.SEMICOLON) || p.peekTokenIs(token.RBRACE) || p.peekToken.NewlineBefore) {
+			break
+		}
+		p.nextToken()
+		switch p.curToken.Type {
+		case token.LPAREN, token.LBRACKET, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACKET, token.RBRACE:
+			depth--
+		}
+	}
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+}
+
+func (p *Parser) parseStatement() ast.Statement {
 	switch p.curToken.Type {
-	case token.LET:
+	case token.LET, token.CONST:
+		if p.peekTokenIs(token.LPAREN) {
+			return p.parseUnpackStatement()
+		}
 		return p.parsetLetStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.IMPORT:
+		return p.parseImportStatement()
+	case token.EXPORT:
+		return p.parseExportStatement()
+	case token.STRUCT:
+		return p.parseStructStatement()
+	case token.ENUM:
+		return p.parseEnumStatement()
+	case token.THROW:
+		return p.parseThrowStatement()
+	case token.DEFER:
+		return p.parseDeferStatement()
+	case token.FOR:
+		return p.parseForStatement()
+	case token.CLASS:
+		return p.parseClassStatement()
+	case token.USE:
+		p.errors = append(p.errors, "use must come before any other statement")
+		p.parseUseStatement()
+		return nil
+	case token.FUNCTION:
+		if p.peekTokenIs(token.IDENT) {
+			return p.parseFunctionStatement()
+		}
+		return p.parseExpressionStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
 }
 
+func (p *Parser) parseClassStatement() ast.Statement {
+	p.require("classes", "class declaration")
+	stmt := &ast.ClassStatement{Token: p.curToken}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	if p.peekTokenIs(token.LESS) {
+		p.nextToken()
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		stmt.Super = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+
+	if decl := p.scopes[len(p.scopes)-1][stmt.Name.Value]; decl != nil {
+		p.errors = append(p.errors, ConstantError("redeclare", decl))
+	}
+	p.declare(stmt.Name, false)
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		if p.curTokenIs(token.SEMICOLON) {
+			continue
+		}
+
+		// methods may be spelled with or without fn
+		fnToken := token.Token{Type: token.FUNCTION, Literal: "fn", Line: p.curToken.Line, Column: p.curToken.Column}
+		if p.curTokenIs(token.FUNCTION) {
+			fnToken = p.curToken
+			p.nextToken()
+		}
+		if !p.curTokenIs(token.IDENT) {
+			p.errors = append(p.errors, fmt.Sprintf("expect method name in class %s, got %s instead", stmt.Name.Value, p.curToken.Type))
+			return nil
+		}
+
+		method := &ast.FunctionLiteral{
+			Token: fnToken,
+			Name:  &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal},
+		}
+		if seen[method.Name.Value] {
+			p.errors = append(p.errors, fmt.Sprintf("duplicate method %s in class %s", method.Name.Value, stmt.Name.Value))
+		}
+		seen[method.Name.Value] = true
+
+		if p.parseFunctionRest(method) == nil {
+			return nil
+		}
+		stmt.Methods = append(stmt.Methods, method)
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseFunctionStatement() ast.Statement {
+	stmt := &ast.FunctionStatement{Token: p.curToken}
+
+	name := &ast.Identifier{Token: p.peekToken, Value: p.peekToken.Literal}
+	if decl := p.scopes[len(p.scopes)-1][name.Value]; decl != nil {
+		p.errors = append(p.errors, ConstantError("redeclare", decl))
+	}
+	// declared before the body so it can call itself
+	p.declare(name, false)
+
+	fn, ok := p.parserFunctionExpression().(*ast.FunctionLiteral)
+	if !ok {
+		return nil
+	}
+	stmt.Function = fn
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
 func (p *Parser) parsetLetStatement() *ast.LetStatement {
 
 	stmt := &ast.LetStatement{
 		Token: p.curToken,
 	}
+	if stmt.IsConst() {
+		p.require("constants", "const")
+	}
 
 	if !p.expectPeek(token.IDENT) {
 		return nil
@@ -406,6 +1103,17 @@ func (p *Parser) parsetLetStatement() *ast.LetStatement {
 
 	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 
+	if decl := p.scopes[len(p.scopes)-1][stmt.Name.Value]; decl != nil {
+		p.errors = append(p.errors, ConstantError("redeclare", decl))
+	}
+
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken()
+		if stmt.Type = p.parseTypeAnnotation(); stmt.Type == nil {
+			return nil
+		}
+	}
+
 	if !p.expectPeek(token.ASSIGN) {
 		return nil
 	}
@@ -414,22 +1122,352 @@ func (p *Parser) parsetLetStatement() *ast.LetStatement {
 	// parser expression
 	stmt.Value = p.parseExpression(LOWEST)
 
-	for !p.curTokenIs(token.SEMICOLON) {
+	p.declare(stmt.Name, stmt.IsConst())
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseUnpackStatement() ast.Statement {
+	stmt := &ast.UnpackStatement{
+		Token: p.curToken,
+	}
+	p.require("tuples", "tuple unpacking")
+	if stmt.IsConst() {
+		p.require("constants", "const")
+	}
+	p.nextToken()
+
+	for !p.peekTokenIs(token.RPAREN) {
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		name := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		if decl := p.scopes[len(p.scopes)-1][name.Value]; decl != nil {
+			p.errors = append(p.errors, ConstantError("redeclare", decl))
+		}
+		stmt.Names = append(stmt.Names, name)
+
+		if !p.peekTokenIs(token.RPAREN) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+	p.nextToken()
+
+	if len(stmt.Names) == 0 {
+		p.errors = append(p.errors, "expect at least one name to unpack into")
+		return nil
+	}
+
+	if !p.expectPeek(token.ASSIGN) {
+		return nil
+	}
+
+	p.nextToken()
+	stmt.Value = p.parseExpression(LOWEST)
+
+	for _, name := range stmt.Names {
+		p.declare(name, stmt.IsConst())
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseUseStatement() *ast.UseStatement {
+	stmt := &ast.UseStatement{Token: p.curToken}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Kind = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	if !p.expectPeek(token.STRING) {
+		return nil
+	}
+	stmt.Value = &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseImportStatement() ast.Statement {
+	p.require("modules", "import")
+	stmt := &ast.ImportStatement{
+		Token: p.curToken,
+	}
+
+	if !p.expectPeek(token.STRING) {
+		return nil
+	}
+
+	stmt.Path = &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
+
+	if p.peekTokenIs(token.AS) {
+		p.nextToken()
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		stmt.Alias = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseExportStatement() ast.Statement {
+	p.require("modules", "export")
+	stmt := &ast.ExportStatement{
+		Token: p.curToken,
+	}
+
+	if !p.peekTokenIs(token.LET) && !p.peekTokenIs(token.CONST) {
+		p.peekError(token.LET)
+		return nil
+	}
+	p.nextToken()
+
+	stmt.Statement = p.parsetLetStatement()
+	if stmt.Statement == nil {
+		return nil
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseStructStatement() ast.Statement {
+	p.require("structs", "struct declaration")
+	stmt := &ast.StructStatement{
+		Token: p.curToken,
+	}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for !p.peekTokenIs(token.RBRACE) {
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		field := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		if seen[field.Value] {
+			p.errors = append(p.errors, fmt.Sprintf("duplicate field %s in struct %s", field.Value, stmt.Name.Value))
+		}
+		seen[field.Value] = true
+		stmt.Fields = append(stmt.Fields, field)
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	p.declare(stmt.Name, false)
+
+	return stmt
+}
+
+func (p *Parser) parseEnumStatement() ast.Statement {
+	p.require("enums", "enum declaration")
+	stmt := &ast.EnumStatement{
+		Token: p.curToken,
+	}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for !p.peekTokenIs(token.RBRACE) {
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		variant := &ast.EnumVariant{
+			Name: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal},
+		}
+		if seen[variant.Name.Value] {
+			p.errors = append(p.errors, fmt.Sprintf("duplicate variant %s in enum %s", variant.Name.Value, stmt.Name.Value))
+		}
+		seen[variant.Name.Value] = true
+
+		if p.peekTokenIs(token.LPAREN) {
+			p.nextToken()
+			variant.Fields = p.parseFunctionParameters()
+			if variant.Fields == nil {
+				return nil
+			}
+		}
+		stmt.Variants = append(stmt.Variants, variant)
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	p.declare(stmt.Name, false)
+
+	return stmt
+}
+
+func (p *Parser) parseThrowStatement() ast.Statement {
+	p.require("exceptions", "throw")
+	stmt := &ast.ThrowStatement{
+		Token: p.curToken,
+	}
+	p.nextToken()
+
+	stmt.Value = p.parseExpression(LOWEST)
+	if stmt.Value == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
 
 	return stmt
 }
 
+func (p *Parser) parseDeferStatement() ast.Statement {
+	p.require("defer", "defer")
+	stmt := &ast.DeferStatement{
+		Token: p.curToken,
+	}
+	p.nextToken()
+
+	stmt.Call = p.parseExpression(LOWEST)
+	if stmt.Call == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseForStatement() ast.Statement {
+	p.require("loops", "for loop")
+	stmt := &ast.ForStatement{
+		Token: p.curToken,
+	}
+
+	stmt.Variables = p.parseLoopVariables()
+	if stmt.Variables == nil {
+		return nil
+	}
+
+	p.nextToken()
+	stmt.Iterable = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	p.enterScope(stmt.Variables)
+	stmt.Body = p.parseBlockStatements()
+	p.leaveScope()
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// x in / k, v in
+func (p *Parser) parseLoopVariables() []*ast.Identifier {
+	vars := []*ast.Identifier{}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	vars = append(vars, &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal})
+
+	if p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		vars = append(vars, &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal})
+	}
+
+	if !p.expectPeek(token.IN) {
+		return nil
+	}
+
+	return vars
+}
+
 func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	ret := &ast.ReturnStatement{
 		Token: p.curToken,
 	}
+
+	// a bare return
+	if p.peekEndsStatement() || p.peekTokenIs(token.RBRACE) {
+		if p.peekTokenIs(token.SEMICOLON) {
+			p.nextToken()
+		}
+		return ret
+	}
+
 	p.nextToken()
 	// parse expression
 	ret.Value = p.parseExpression(LOWEST)
 
-	for !p.curTokenIs(token.SEMICOLON) {
+	// return a, b returns a tuple
+	if p.peekTokenIs(token.COMMA) {
+		p.require("tuples", "returning several values")
+		tuple := &ast.TupleLiteral{Token: ret.Token, Elements: []ast.Expression{ret.Value}}
+		for p.peekTokenIs(token.COMMA) {
+			p.nextToken()
+			p.nextToken()
+			tuple.Elements = append(tuple.Elements, p.parseExpression(LOWEST))
+		}
+		ret.Value = tuple
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
 
@@ -457,7 +1495,7 @@ func (p *Parser) parseExpression(proecedence int) ast.Expression {
 	}
 
 	leftExp := prefix()
-	for !p.peekTokenIs(token.SEMICOLON) && proecedence < p.peekPrecedence() {
+	for !p.peekEndsStatement() && proecedence < p.peekPrecedence() {
 		infix := p.infixParseFns[p.peekToken.Type]
 
 		if infix == nil {
@@ -470,6 +1508,41 @@ func (p *Parser) parseExpression(proecedence int) ast.Expression {
 	return leftExp
 }
 
+// peekEndsStatement reports whether the statement ends before the peek
+// token: at a semicolon, the end of input, or a line break outside brackets.
+// A line starting with '.' continues a method chain.
+func (p *Parser) peekEndsStatement() bool {
+	switch {
+	case p.peekTokenIs(token.SEMICOLON), p.peekTokenIs(token.EOF):
+		return true
+	case p.peekTokenIs(token.DOT):
+		return false
+	}
+	return p.nesting == 0 && p.peekToken.NewlineBefore
+}
+
+// expectStatementEnd reports a statement that is not followed by ;, a line
+// break, } or the end of the input. A statement ending in a block needs no
+// separator.
+func (p *Parser) expectStatementEnd() {
+	switch {
+	case p.curTokenIs(token.SEMICOLON), p.curTokenIs(token.RBRACE), p.peekTokenIs(token.SEMICOLON),
+		p.peekTokenIs(token.RBRACE), p.peekTokenIs(token.EOF):
+	case p.peekToken.NewlineBefore:
+		p.require("optional-semicolons", "ending a statement at a line break")
+	default:
+		p.errors = append(p.errors, fmt.Sprintf("expected ; or newline, got %s", p.peekToken.Literal))
+	}
+}
+
+func (p *Parser) nest() {
+	p.nesting++
+}
+
+func (p *Parser) unnest() {
+	p.nesting--
+}
+
 func (p *Parser) peekTokenIs(tok token.TokenType) bool {
 
 	return p.peekToken.Type == tok
@@ -480,7 +1553,7 @@ func (p *Parser) curTokenIs(tok token.TokenType) bool {
 }
 
 func (p *Parser) peekPrecedence() int {
-	if p, ok := precedences[p.peekToken.Type]; ok {
+	if p, ok := p.precedences[p.peekToken.Type]; ok {
 		return p
 	}
 
@@ -488,7 +1561,7 @@ func (p *Parser) peekPrecedence() int {
 }
 
 func (p *Parser) curPrecedence() int {
-	if p, ok := precedences[p.curToken.Type]; ok {
+	if p, ok := p.precedences[p.curToken.Type]; ok {
 		return p
 	}
 
@@ -520,12 +1593,51 @@ func (p *Parser) noPrefixParserFnError(t token.TokenType) {
 	p.errors = append(p.errors, msg)
 }
 
+// scope tracking
+
+func (p *Parser) enterScope(params []*ast.Identifier) {
+	p.scopes = append(p.scopes, map[string]*ast.Identifier{})
+	for _, param := range params {
+		p.declare(param, false)
+	}
+}
+
+func (p *Parser) leaveScope() {
+	p.scopes = p.scopes[:len(p.scopes)-1]
+}
+
+func (p *Parser) declare(name *ast.Identifier, constant bool) {
+	scope := p.scopes[len(p.scopes)-1]
+	if constant {
+		scope[name.Value] = name
+	} else {
+		scope[name.Value] = nil
+	}
+}
+
+// lookupConstant returns the declaration of name if the innermost binding of it is a constant.
+func (p *Parser) lookupConstant(name string) *ast.Identifier {
+	for i := len(p.scopes) - 1; i >= 0; i-- {
+		if decl, ok := p.scopes[i][name]; ok {
+			return decl
+		}
+	}
+	return nil
+}
+
+// ConstantError describes an attempt to rebind a constant, e.g.
+// "cannot assign to constant x (declared at line 1, column 7)".
+func ConstantError(action string, decl *ast.Identifier) string {
+	return fmt.Sprintf("cannot %s constant %s (declared at line %d, column %d)",
+		action, decl.Value, decl.Token.Line, decl.Token.Column)
+}
+
 // register function
 
-func (p *Parser) registerPrefix(tokType token.TokenType, fn prefixParseFn) {
+func (p *Parser) registerPrefix(tokType token.TokenType, fn PrefixParseFn) {
 	p.prefixParseFns[tokType] = fn
 }
 
-func (p *Parser) registerInFix(tokType token.TokenType, fn infixParseFn) {
+func (p *Parser) registerInFix(tokType token.TokenType, fn InfixParseFn) {
 	p.infixParseFns[tokType] = fn
 }
@@ -193,3 +193,643 @@ func TestReturnStatements(t *testing.T) {
 		t.Errorf("expect %d, got %d", 3, idt3.Value)
 	}
 }
+
+func TestImportStatements(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectPath   string
+		expectAlias  string
+		expectString string
+	}{
+		{`import "lib/strings.mk";`, "lib/strings.mk", "", `import "lib/strings.mk";`},
+		{`import "lib/strings.mk" as strs;`, "lib/strings.mk", "strs", `import "lib/strings.mk" as strs;`},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("expect 1 statements, got %d", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ImportStatement)
+		if !ok {
+			t.Fatalf("expect importStatement, got %T", program.Statements[0])
+		}
+
+		if stmt.Path.Value != itm.expectPath {
+			t.Errorf("expect path %s, got %s", itm.expectPath, stmt.Path.Value)
+		}
+
+		if itm.expectAlias == "" && stmt.Alias != nil {
+			t.Errorf("expect no alias, got %s", stmt.Alias)
+		}
+
+		if itm.expectAlias != "" && !testIdentifier(t, stmt.Alias, itm.expectAlias) {
+			return
+		}
+
+		if stmt.String() != itm.expectString {
+			t.Errorf("expect %s, got %s", itm.expectString, stmt.String())
+		}
+	}
+}
+
+func TestExportStatement(t *testing.T) {
+	input := `export let add = fn(x, y) { x + y };`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*ast.ExportStatement)
+	if !ok {
+		t.Fatalf("expect exportStatement, got %T", program.Statements[0])
+	}
+
+	if !testLetStatement(t, stmt.Statement, "add") {
+		return
+	}
+
+	if _, ok := stmt.Statement.Value.(*ast.FunctionLiteral); !ok {
+		t.Errorf("expect functionLiteral value, got %T", stmt.Statement.Value)
+	}
+}
+
+func TestConstStatement(t *testing.T) {
+	input := `const x = 5;`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*ast.LetStatement)
+	if !ok {
+		t.Fatalf("expect letStatement, got %T", program.Statements[0])
+	}
+
+	if !stmt.IsConst() {
+		t.Errorf("expect const statement")
+	}
+
+	if stmt.String() != "const x = 5;" {
+		t.Errorf("expect const x = 5;, got %s", stmt.String())
+	}
+}
+
+func TestAssignExpression(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"x = 5;", "(x = 5)"},
+		{"x = y = 1 + 2;", "(x = (y = (1 + 2)))"},
+		{"x = fn(a) { a = a * 2; a; }", "(x = fn(a)(a = (a * 2))a)"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+}
+
+func TestConstantErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{
+			"const x = 1;\nx = 2;",
+			"cannot assign to constant x (declared at line 1, column 7)",
+		},
+		{
+			"const x = 1;\nlet x = 2;",
+			"cannot redeclare constant x (declared at line 1, column 7)",
+		},
+		{
+			"const x = 1; let f = fn() { x = 2; };",
+			"cannot assign to constant x (declared at line 1, column 7)",
+		},
+		{
+			"1 + 2 = 3;",
+			"cannot assign to (1 + 2)",
+		},
+		{
+			"fn(1) = 3",
+			"expect next token to be {, got = instead",
+		},
+		{
+			"fn(x = 3) { x }",
+			"expect next token to be ), got = instead",
+		},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		p.ParserProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("%q: expect parse error %q", itm.input, itm.expect)
+			continue
+		}
+
+		if errors[0] != itm.expect {
+			t.Errorf("expect error %q, got %q", itm.expect, errors[0])
+		}
+	}
+}
+
+func TestConstantShadowing(t *testing.T) {
+	inputs := []string{
+		"const x = 1; let f = fn(x) { x = 2; };",
+		"const x = 1; let f = fn() { let x = 2; x = 3; };",
+		"if (true) { const y = 1; } let y = 2; y = 3;",
+		"const z = 1; if (true) { let z = 2; z = 3; } else { let z = 4; }",
+		"try { const t = 1; } finally { let t = 2; } let t = 3;",
+	}
+
+	for _, input := range inputs {
+		l := lexer.New(input)
+		p := New(l)
+		p.ParserProgram()
+		CheckParserErrors(t, p)
+	}
+}
+
+func TestStructStatement(t *testing.T) {
+	input := `struct Point { x, y }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("expect 1 statements, got %d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.StructStatement)
+	if !ok {
+		t.Fatalf("expect structStatement, got %T", program.Statements[0])
+	}
+
+	if !testIdentifier(t, stmt.Name, "Point") {
+		return
+	}
+
+	if len(stmt.Fields) != 2 {
+		t.Fatalf("expect 2 fields, got %d", len(stmt.Fields))
+	}
+
+	testIdentifier(t, stmt.Fields[0], "x")
+	testIdentifier(t, stmt.Fields[1], "y")
+
+	if stmt.String() != "struct Point { x, y }" {
+		t.Errorf("expect struct Point { x, y }, got %s", stmt.String())
+	}
+
+	p = New(lexer.New(`struct Point { x, x }`))
+	p.ParserProgram()
+	if len(p.Errors()) != 1 || p.Errors()[0] != "duplicate field x in struct Point" {
+		t.Errorf("expect duplicate field error, got %v", p.Errors())
+	}
+}
+
+func TestEnumStatement(t *testing.T) {
+	input := `enum Shape { Circle(r), Rect(w, h), Empty }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*ast.EnumStatement)
+	if !ok {
+		t.Fatalf("expect enumStatement, got %T", program.Statements[0])
+	}
+
+	if !testIdentifier(t, stmt.Name, "Shape") {
+		return
+	}
+
+	tests := []struct {
+		name   string
+		fields []string
+	}{
+		{"Circle", []string{"r"}},
+		{"Rect", []string{"w", "h"}},
+		{"Empty", nil},
+	}
+
+	if len(stmt.Variants) != len(tests) {
+		t.Fatalf("expect %d variants, got %d", len(tests), len(stmt.Variants))
+	}
+
+	for i, itm := range tests {
+		variant := stmt.Variants[i]
+		testIdentifier(t, variant.Name, itm.name)
+
+		if itm.fields == nil && variant.Fields != nil {
+			t.Errorf("expect %s without payload, got %v", itm.name, variant.Fields)
+		}
+
+		if len(variant.Fields) != len(itm.fields) {
+			t.Errorf("expect %d fields, got %d", len(itm.fields), len(variant.Fields))
+			continue
+		}
+
+		for j, field := range itm.fields {
+			testIdentifier(t, variant.Fields[j], field)
+		}
+	}
+
+	if stmt.String() != input {
+		t.Errorf("expect %s, got %s", input, stmt.String())
+	}
+}
+
+func TestDeferStatement(t *testing.T) {
+	input := `fn() { defer close(f); }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	function := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.FunctionLiteral)
+	stmt, ok := function.Body.Statements[0].(*ast.DeferStatement)
+	if !ok {
+		t.Fatalf("expect deferStatement, got %T", function.Body.Statements[0])
+	}
+
+	if stmt.String() != "defer close(f);" {
+		t.Errorf("expect defer close(f);, got %s", stmt.String())
+	}
+}
+
+func TestForStatement(t *testing.T) {
+	tests := []struct {
+		input  string
+		vars   []string
+		expect string
+	}{
+		{"for x in xs { puts(x); }", []string{"x"}, "for x in xs { puts(x) }"},
+		{"for k, v in h { puts(k, v); }", []string{"k", "v"}, "for k, v in h { puts(k, v) }"},
+		{"for x in [1, 2] { x }", []string{"x"}, "for x in [1, 2] { x }"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		stmt, ok := program.Statements[0].(*ast.ForStatement)
+		if !ok {
+			t.Fatalf("expect forStatement, got %T", program.Statements[0])
+		}
+
+		if len(stmt.Variables) != len(itm.vars) {
+			t.Fatalf("expect %d variables, got %d", len(itm.vars), len(stmt.Variables))
+		}
+
+		for i, name := range itm.vars {
+			testIdentifier(t, stmt.Variables[i], name)
+		}
+
+		if stmt.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, stmt.String())
+		}
+	}
+
+	p := New(lexer.New("for x in xs { puts(x) }; 1"))
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	if len(program.Statements) != 2 {
+		t.Errorf("expect 2 statements, got %d", len(program.Statements))
+	}
+}
+
+func TestFunctionStatement(t *testing.T) {
+	input := `fn add(x, y) { x + y }; fn() { 1 }; let f = fn named(n) { n };`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	if len(program.Statements) != 3 {
+		t.Fatalf("expect 3 statements, got %d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.FunctionStatement)
+	if !ok {
+		t.Fatalf("expect FunctionStatement, got %T", program.Statements[0])
+	}
+	if !testIdentifier(t, stmt.Function.Name, "add") {
+		return
+	}
+	if len(stmt.Function.Parameters) != 2 {
+		t.Errorf("expect 2 parameters, got %d", len(stmt.Function.Parameters))
+	}
+	if stmt.String() != "fn add(x, y)(x + y)" {
+		t.Errorf("wrong string %s", stmt.String())
+	}
+
+	if _, ok := program.Statements[1].(*ast.ExpressionStatement); !ok {
+		t.Errorf("expect anonymous function to stay an expression, got %T", program.Statements[1])
+	}
+
+	let := program.Statements[2].(*ast.LetStatement)
+	if let.Value.String() != "fn named(n)n" {
+		t.Errorf("wrong string %s", let.Value.String())
+	}
+}
+
+func TestFunctionStatementConstant(t *testing.T) {
+	p := New(lexer.New("const f = 1; fn f() { 2 }"))
+	p.ParserProgram()
+
+	expect := "cannot redeclare constant f (declared at line 1, column 7)"
+	if len(p.Errors()) != 1 || p.Errors()[0] != expect {
+		t.Errorf("expect error %q, got %v", expect, p.Errors())
+	}
+}
+
+func TestOptionalSemicolons(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect []string
+	}{
+		{"let x = 5\nlet y = x + 1\ny", []string{"let x = 5;", "let y = (x + 1);", "y"}},
+		{"let x = 5", []string{"let x = 5;"}},
+		{"return 1\nreturn", []string{"return 1;", "return ;"}},
+		{"let f = fn(x) {\n  let y = x\n  y * 2\n}", []string{"let f = fn(x)let y = x;(y * 2);"}},
+		{"a\n-1", []string{"a", "(-1)"}},
+		{"a -\n1", []string{"(a - 1)"}},
+		{"(a\n- 1)", []string{"(a - 1)"}},
+		{"f(a,\n  b)\n[1]", []string{"f(a, b)", "[1]"}},
+		{"[a\n- 1, fn() { x\n-1 }]", []string{"[(a - 1), fn()x(-1)]"}},
+		{"xs\n  .first", []string{"(xs.first)"}},
+		{"if (x) { 1 }\nelse { 2 }", []string{"ifx 12"}},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if len(program.Statements) != len(itm.expect) {
+			t.Errorf("%q: expect %d statements, got %d: %s", itm.input, len(itm.expect), len(program.Statements), program.String())
+			continue
+		}
+		for i, stmt := range program.Statements {
+			if stmt.String() != itm.expect[i] {
+				t.Errorf("%q: expect statement %d to be %s, got %s", itm.input, i, itm.expect[i], stmt.String())
+			}
+		}
+	}
+}
+
+func TestMissingStatementSeparator(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"let x = 5 let y = 6", "expected ; or newline, got let"},
+		{"return 1 2", "expected ; or newline, got 2"},
+		{"x y", "expected ; or newline, got y"},
+		{"let f = fn() { let a = 1 a }", "expected ; or newline, got a"},
+	}
+
+	for _, itm := range tests {
+		p := New(lexer.New(itm.input))
+		p.ParserProgram()
+
+		if len(p.Errors()) != 1 || p.Errors()[0] != itm.expect {
+			t.Errorf("%q: expect error %q, got %v", itm.input, itm.expect, p.Errors())
+		}
+	}
+
+	for _, input := range []string{"let x = 5; let y = 6", "if (x) { 1 } y", "fn f() { 1 } f()", "let f = fn() { 1 }"} {
+		p := New(lexer.New(input))
+		p.ParserProgram()
+		CheckParserErrors(t, p)
+	}
+}
+
+func TestTrailingCommas(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"[1, 2,]", "[1, 2]"},
+		{"[\n  1,\n  2,\n]", "[1, 2]"},
+		{"{\"a\": 1, \"b\": 2,}", "{a:1, b:2}"},
+		{"f(1, 2,)", "f(1, 2)"},
+		{"fn(x, y,) { x }", "fn(x, y)x"},
+		{"fn(x: int,) { x }", "fn(x: int)x"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+
+	for _, input := range []string{"[,]", "f(,)", "[1,,]"} {
+		p := New(lexer.New(input))
+		p.ParserProgram()
+		if len(p.Errors()) == 0 {
+			t.Errorf("%s: expect parse errors", input)
+		}
+	}
+}
+
+func TestClassStatement(t *testing.T) {
+	input := `
+class Dog < Animal {
+	init(name) { self.name = name }
+	fn speak() -> string { self.name + " barks" }
+}`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+	CheckParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("expect 1 statements, got %d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ClassStatement)
+	if !ok {
+		t.Fatalf("expect ClassStatement, got %T", program.Statements[0])
+	}
+	if !testIdentifier(t, stmt.Name, "Dog") || !testIdentifier(t, stmt.Super, "Animal") {
+		return
+	}
+	if len(stmt.Methods) != 2 {
+		t.Fatalf("expect 2 methods, got %d", len(stmt.Methods))
+	}
+	if !testIdentifier(t, stmt.Methods[0].Name, "init") || !testIdentifier(t, stmt.Methods[1].Name, "speak") {
+		return
+	}
+
+	expect := "class Dog < Animal { fn init(name)(self.name = name) fn speak() -> string ((self.name) +  barks) }"
+	if stmt.String() != expect {
+		t.Errorf("expect %s, got %s", expect, stmt.String())
+	}
+}
+
+func TestCompoundAssignment(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"x += 1", "(x += 1)"},
+		{"x -= y * 2", "(x -= (y * 2))"},
+		{"x *= 2", "(x *= 2)"},
+		{"x /= 2", "(x /= 2)"},
+		{"self.n = 1", "(self.n = 1)"},
+		{"a.b.c += 1", "((a.b).c += 1)"},
+		{"x = y += 1", "(x = (y += 1))"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+
+	errors := []struct {
+		input  string
+		expect string
+	}{
+		{"const x = 1; x += 1", "cannot assign to constant x (declared at line 1, column 7)"},
+		{"f() += 1", "cannot assign to f()"},
+		{"class C { 1 }", "expect method name in class C, got INT instead"},
+		{"class C { m() { 1 } fn m(x) { x } }", "duplicate method m in class C"},
+	}
+
+	for _, itm := range errors {
+		p := New(lexer.New(itm.input))
+		p.ParserProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != itm.expect {
+			t.Errorf("%s: expect error %q, got %v", itm.input, itm.expect, p.Errors())
+		}
+	}
+}
+
+func TestTuples(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"(1, 2)", "(1, 2)"},
+		{"(1,)", "(1,)"},
+		{"()", "()"},
+		{"(1)", "1"},
+		{"(a + 1, f(b), [c])", "((a + 1), f(b), [c])"},
+		{"(1, 2,)", "(1, 2)"},
+		{"return a, b", "return (a, b);"},
+		{"return (a, b)", "return (a, b);"},
+		{"let (x, y) = f();", "let (x, y) = f();"},
+		{"const (q, r,) = (1, 2)", "const (q, r) = (1, 2);"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+
+	errors := []struct {
+		input  string
+		expect string
+	}{
+		{"let () = f()", "expect at least one name to unpack into"},
+		{"const x = 1; let (x, y) = f()", "cannot redeclare constant x (declared at line 1, column 7)"},
+		{"let (x y) = f()", "expect next token to be ,, got IDENT instead"},
+	}
+
+	for _, itm := range errors {
+		p := New(lexer.New(itm.input))
+		p.ParserProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != itm.expect {
+			t.Errorf("%s: expect error %q, got %v", itm.input, itm.expect, p.Errors())
+		}
+	}
+}
+
+func TestKeywordArguments(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"request(url, timeout: 30, retry: true)", "request(url, timeout: 30, retry: true)"},
+		{"f(x: a + 1)", "f(x: (a + 1))"},
+		{"f(\n  a: 1,\n  b: 2,\n)", "f(a: 1, b: 2)"},
+		{"f({a: 1})", "f({a:1})"},
+	}
+
+	for _, itm := range tests {
+		l := lexer.New(itm.input)
+		p := New(l)
+		program := p.ParserProgram()
+		CheckParserErrors(t, p)
+
+		if program.String() != itm.expect {
+			t.Errorf("expect %s, got %s", itm.expect, program.String())
+		}
+	}
+
+	errors := []struct {
+		input  string
+		expect string
+	}{
+		{"f(a: 1, 2)", "positional argument after keyword argument"},
+		{"f(a: 1, a: 2)", "duplicate keyword argument a"},
+	}
+
+	for _, itm := range errors {
+		p := New(lexer.New(itm.input))
+		p.ParserProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != itm.expect {
+			t.Errorf("%s: expect error %q, got %v", itm.input, itm.expect, p.Errors())
+		}
+	}
+}
@@ -17,6 +17,9 @@ func Repl() {
 
 	scanner := bufio.NewScanner(os.Stdin)
 	env := object.NewEnvironment()
+	macroEnv := object.NewEnvironment()
+	// pragmas stay in effect for the following lines
+	features, _ := parser.EditionFeatures(parser.LatestEdition)
 	for {
 		fmt.Fprint(os.Stdout, PROMPT)
 
@@ -29,6 +32,7 @@ func Repl() {
 		line := scanner.Text()
 		l := lexer.New(line)
 		p := parser.New(l)
+		p.SetFeatures(features)
 
 		program := p.ParserProgram()
 
@@ -36,18 +40,40 @@ func Repl() {
 			printParseErrors(os.Stderr, p.Errors())
 			continue
 		}
-		result := evaluator.Eval(program, env)
+		features = p.Features()
+		evaluator.DefineMacros(program, macroEnv)
+		expanded, err := evaluator.ExpandMacros(program, macroEnv)
+		if err != nil {
+			io.WriteString(os.Stdout, err.Inspect()+"\n")
+			continue
+		}
+
+		result := evaluator.Eval(expanded, env)
 
 		if result != nil {
-			io.WriteString(os.Stdout, result.Inspect())
+			io.WriteString(os.Stdout, evaluator.Inspect(result))
 			io.WriteString(os.Stdout, "\n")
-			io.WriteString(os.Stdout, program.String())
+			if errObj, ok := result.(*object.Error); ok {
+				io.WriteString(os.Stdout, errObj.StackTrace())
+			}
+			io.WriteString(os.Stdout, expanded.String())
 			io.WriteString(os.Stdout, "\n")
 		}
 
 	}
 }
 
+// RunFile evaluates the script at path as the main module.
+func RunFile(path string) {
+	result := evaluator.RunFile(path)
+
+	if errObj, ok := result.(*object.Error); ok {
+		io.WriteString(os.Stderr, errObj.Inspect()+"\n")
+		io.WriteString(os.Stderr, errObj.StackTrace())
+		os.Exit(1)
+	}
+}
+
 func printParseErrors(out io.Writer, errors []string) {
 
 	for _, err := range errors {
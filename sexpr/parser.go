@@ -0,0 +1,844 @@
+// Package sexpr reads and writes Monkey programs in s-expression form,
+// e.g. (let x (+ 1 2)). Parse produces the same ast nodes as the parser
+// package and Print turns any node back into an s-expression.
+package sexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/lexer"
+	"com.language/monkey/token"
+)
+
+// heads of special forms; a call of a function with one of these names is
+// written (call name args...)
+var specialForms = map[string]bool{
+	"let": true, "const": true, "return": true, "import": true, "export": true,
+	"struct": true, "enum": true, "throw": true, "defer": true, "for": true,
+	"class": true, "defn": true, "fn": true, "macro": true, "if": true,
+	"try": true, "catch": true, "finally": true, "match": true, "yield": true,
+	"do": true, "index": true, "tuple": true, "array-comp": true,
+	"hash-comp": true, "call": true, "in": true, "unknown": true, "use": true,
+}
+
+var assignOperators = map[string]bool{"=": true, "+=": true, "-=": true, "*=": true, "/=": true}
+
+// the operators the Monkey parser accepts in prefix and in infix position
+var (
+	prefixOperators = map[string]bool{"!": true, "-": true}
+	infixOperators  = map[string]bool{
+		"+": true, "-": true, "*": true, "/": true, "<": true, ">": true,
+		"<=": true, ">=": true, "==": true, "!=": true, "in": true,
+	}
+)
+
+type formParser struct {
+	// innermost last; a yield marks the function it appears in as a generator
+	functions []*ast.FunctionLiteral
+}
+
+// Parse reads an s-expression program into an ast.Program.
+func Parse(input string) (*ast.Program, error) {
+	forms, err := read(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &formParser{}
+	program := &ast.Program{Statements: []ast.Statement{}}
+	for _, f := range forms {
+		stmt, err := p.parseStatement(f)
+		if err != nil {
+			return nil, err
+		}
+		program.Statements = append(program.Statements, stmt)
+	}
+	return program, nil
+}
+
+func errorf(f *form, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d, column %d: %s", f.Token.Line, f.Token.Column, fmt.Sprintf(format, args...))
+}
+
+// tok returns a token of type typ for literal, positioned at f.
+func tok(f *form, typ token.TokenType, literal string) token.Token {
+	return token.Token{Type: typ, Literal: literal, Line: f.Token.Line, Column: f.Token.Column}
+}
+
+func keyword(f *form, name string) token.Token {
+	return tok(f, token.LoopupIdentifier(name), name)
+}
+
+// operatorToken types an operator the way the lexer does: "+" is PLUS, "in" is IN.
+func operatorToken(f *form, op string) token.Token {
+	if isOperator(op) {
+		return tok(f, token.TokenType(op), op)
+	}
+	return keyword(f, op)
+}
+
+func (p *formParser) parseStatement(f *form) (ast.Statement, error) {
+	items := f.Items
+
+	switch f.head() {
+	case "let", "const":
+		return p.parseLet(f)
+	case "return":
+		stmt := &ast.ReturnStatement{Token: keyword(f, "return")}
+		if len(items) > 2 {
+			return nil, errorf(f, "return takes at most one value")
+		}
+		if len(items) == 2 {
+			value, err := p.parseExpression(items[1])
+			if err != nil {
+				return nil, err
+			}
+			stmt.Value = value
+		}
+		return stmt, nil
+	case "import":
+		if len(items) < 2 || len(items) > 3 || items[1].Token.Type != token.STRING || items[1].Delim != 0 {
+			return nil, errorf(f, "expect (import \"path\" alias?)")
+		}
+		stmt := &ast.ImportStatement{Token: keyword(f, "import"), Path: &ast.StringLiteral{Token: items[1].Token, Value: items[1].Token.Literal}}
+		if len(items) == 3 {
+			alias, err := parseIdentifier(items[2])
+			if err != nil {
+				return nil, err
+			}
+			stmt.Alias = alias
+		}
+		return stmt, nil
+	case "export":
+		if len(items) != 2 {
+			return nil, errorf(f, "expect (export (let name value))")
+		}
+		inner, err := p.parseStatement(items[1])
+		if err != nil {
+			return nil, err
+		}
+		let, ok := inner.(*ast.LetStatement)
+		if !ok {
+			return nil, errorf(items[1], "only let and const can be exported")
+		}
+		return &ast.ExportStatement{Token: keyword(f, "export"), Statement: let}, nil
+	case "struct":
+		if len(items) < 2 {
+			return nil, errorf(f, "expect (struct Name fields...)")
+		}
+		names, err := parseIdentifiers(items[1:])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.StructStatement{Token: keyword(f, "struct"), Name: names[0], Fields: names[1:]}, nil
+	case "enum":
+		return p.parseEnum(f)
+	case "use":
+		if len(items) != 3 || items[2].Token.Type != token.STRING || items[2].Delim != 0 {
+			return nil, errorf(f, "expect (use edition \"name\") or (use feature \"name\")")
+		}
+		kind, err := parseIdentifier(items[1])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.UseStatement{Token: keyword(f, "use"), Kind: kind, Value: &ast.StringLiteral{Token: items[2].Token, Value: items[2].Token.Literal}}, nil
+	case "throw":
+		if len(items) != 2 {
+			return nil, errorf(f, "expect (throw value)")
+		}
+		value, err := p.parseExpression(items[1])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.ThrowStatement{Token: keyword(f, "throw"), Value: value}, nil
+	case "defer":
+		if len(items) != 2 {
+			return nil, errorf(f, "expect (defer call)")
+		}
+		call, err := p.parseExpression(items[1])
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := call.(*ast.CallExpression); !ok {
+			return nil, errorf(items[1], "defer expects a call")
+		}
+		return &ast.DeferStatement{Token: keyword(f, "defer"), Call: call}, nil
+	case "for":
+		return p.parseFor(f)
+	case "class":
+		return p.parseClass(f)
+	case "defn":
+		if len(items) < 2 || !items[1].isSymbol() {
+			return nil, errorf(f, "expect (defn name (params) body...)")
+		}
+		fn, err := p.parseFunction(f, 1)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.FunctionStatement{Token: fn.Token, Function: fn}, nil
+	}
+
+	exp, err := p.parseExpression(f)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.ExpressionStatement{Token: f.Token, Expression: exp}, nil
+}
+
+// (let x value), (let x type value) or (let (x y) value); const alike.
+func (p *formParser) parseLet(f *form) (ast.Statement, error) {
+	items := f.Items
+	name := items[0].Token.Literal
+	if len(items) != 3 && len(items) != 4 {
+		return nil, errorf(f, "expect (%s name type? value)", name)
+	}
+
+	value, err := p.parseExpression(items[len(items)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	if items[1].Delim == '(' {
+		if len(items) != 3 {
+			return nil, errorf(f, "unpacking takes no type")
+		}
+		names, err := parseIdentifiers(items[1].Items)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return nil, errorf(items[1], "expect at least one name to unpack into")
+		}
+		return &ast.UnpackStatement{Token: keyword(f, name), Names: names, Value: value}, nil
+	}
+
+	stmt := &ast.LetStatement{Token: keyword(f, name), Value: value}
+	if stmt.Name, err = parseIdentifier(items[1]); err != nil {
+		return nil, err
+	}
+	if len(items) == 4 {
+		if stmt.Type, err = parseIdentifier(items[2]); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+// (enum Name Plain (WithFields a b))
+func (p *formParser) parseEnum(f *form) (ast.Statement, error) {
+	if len(f.Items) < 2 {
+		return nil, errorf(f, "expect (enum Name variants...)")
+	}
+	name, err := parseIdentifier(f.Items[1])
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := &ast.EnumStatement{Token: keyword(f, "enum"), Name: name}
+	for _, item := range f.Items[2:] {
+		variant := &ast.EnumVariant{}
+		if item.Delim == '(' {
+			names, err := parseIdentifiers(item.Items)
+			if err != nil {
+				return nil, err
+			}
+			if len(names) == 0 {
+				return nil, errorf(item, "expect a variant name")
+			}
+			variant.Name, variant.Fields = names[0], names[1:]
+		} else if variant.Name, err = parseIdentifier(item); err != nil {
+			return nil, err
+		}
+		stmt.Variants = append(stmt.Variants, variant)
+	}
+	return stmt, nil
+}
+
+// (for (x) iterable body...)
+func (p *formParser) parseFor(f *form) (ast.Statement, error) {
+	if len(f.Items) < 3 || f.Items[1].Delim != '(' {
+		return nil, errorf(f, "expect (for (names) iterable body...)")
+	}
+	vars, err := parseIdentifiers(f.Items[1].Items)
+	if err != nil {
+		return nil, err
+	}
+	if len(vars) < 1 || len(vars) > 2 {
+		return nil, errorf(f.Items[1], "for binds one or two names")
+	}
+
+	stmt := &ast.ForStatement{Token: keyword(f, "for"), Variables: vars}
+	if stmt.Iterable, err = p.parseExpression(f.Items[2]); err != nil {
+		return nil, err
+	}
+	if stmt.Body, err = p.parseBlock(f, f.Items[3:]); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// (class Name methods...) or (class (Name Super) methods...)
+func (p *formParser) parseClass(f *form) (ast.Statement, error) {
+	if len(f.Items) < 2 {
+		return nil, errorf(f, "expect (class Name methods...)")
+	}
+
+	stmt := &ast.ClassStatement{Token: keyword(f, "class")}
+	var err error
+	if names := f.Items[1]; names.Delim == '(' {
+		if len(names.Items) != 2 {
+			return nil, errorf(names, "expect (Name Super)")
+		}
+		if stmt.Name, err = parseIdentifier(names.Items[0]); err != nil {
+			return nil, err
+		}
+		if stmt.Super, err = parseIdentifier(names.Items[1]); err != nil {
+			return nil, err
+		}
+	} else if stmt.Name, err = parseIdentifier(names); err != nil {
+		return nil, err
+	}
+
+	for _, item := range f.Items[2:] {
+		if item.head() != "fn" || len(item.Items) < 2 || !item.Items[1].isSymbol() {
+			return nil, errorf(item, "expect a method (fn name (params) body...) in class %s", stmt.Name.Value)
+		}
+		method, err := p.parseFunction(item, 1)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Methods = append(stmt.Methods, method)
+	}
+	return stmt, nil
+}
+
+// parseFunction reads `name? (params) options... body...` from f.Items[start:].
+// Options are (-> type), (requires cond) and (ensures cond).
+func (p *formParser) parseFunction(f *form, start int) (*ast.FunctionLiteral, error) {
+	fn := &ast.FunctionLiteral{Token: keyword(f, "fn")}
+	items := f.Items[start:]
+
+	var err error
+	if len(items) > 0 && items[0].isSymbol() {
+		if fn.Name, err = parseIdentifier(items[0]); err != nil {
+			return nil, err
+		}
+		items = items[1:]
+	}
+
+	if len(items) == 0 || items[0].Delim != '(' {
+		return nil, errorf(f, "expect a parameter list")
+	}
+	for _, param := range items[0].Items {
+		var name, typ *ast.Identifier
+		if param.Delim == '(' {
+			if len(param.Items) != 2 {
+				return nil, errorf(param, "expect (name type)")
+			}
+			if name, err = parseIdentifier(param.Items[0]); err != nil {
+				return nil, err
+			}
+			if typ, err = parseIdentifier(param.Items[1]); err != nil {
+				return nil, err
+			}
+		} else if name, err = parseIdentifier(param); err != nil {
+			return nil, err
+		}
+		fn.Parameters = append(fn.Parameters, name)
+		fn.ParameterTypes = append(fn.ParameterTypes, typ)
+	}
+	if fn.Parameters == nil {
+		fn.Parameters = []*ast.Identifier{}
+		fn.ParameterTypes = []*ast.Identifier{}
+	}
+	items = items[1:]
+
+	for len(items) > 0 {
+		option := items[0].head()
+		if option != "->" && option != "requires" && option != "ensures" {
+			break
+		}
+		if len(items[0].Items) != 2 {
+			return nil, errorf(items[0], "expect (%s value)", option)
+		}
+		if option == "->" {
+			if fn.ReturnType, err = parseIdentifier(items[0].Items[1]); err != nil {
+				return nil, err
+			}
+		} else {
+			cond, err := p.parseExpression(items[0].Items[1])
+			if err != nil {
+				return nil, err
+			}
+			if option == "requires" {
+				fn.Requires = append(fn.Requires, cond)
+			} else {
+				fn.Ensures = append(fn.Ensures, cond)
+			}
+		}
+		items = items[1:]
+	}
+
+	p.functions = append(p.functions, fn)
+	fn.Body, err = p.parseBlock(f, items)
+	p.functions = p.functions[:len(p.functions)-1]
+	if err != nil {
+		return nil, err
+	}
+	if fn.IsGenerator && len(fn.Ensures) > 0 {
+		return nil, errorf(f, "ensures clause on a generator function")
+	}
+	return fn, nil
+}
+
+func (p *formParser) parseBlock(f *form, items []*form) (*ast.BlockStatements, error) {
+	block := &ast.BlockStatements{Token: tok(f, token.LBRACE, "{"), Statements: []ast.Statement{}}
+	for _, item := range items {
+		stmt, err := p.parseStatement(item)
+		if err != nil {
+			return nil, err
+		}
+		block.Statements = append(block.Statements, stmt)
+	}
+	return block, nil
+}
+
+// parseDo reads a (do statements...) block.
+func (p *formParser) parseDo(f *form) (*ast.BlockStatements, error) {
+	if f.head() != "do" {
+		return nil, errorf(f, "expect (do statements...)")
+	}
+	return p.parseBlock(f, f.Items[1:])
+}
+
+func (p *formParser) parseExpression(f *form) (ast.Expression, error) {
+	switch f.Delim {
+	case 0:
+		return parseAtom(f)
+	case '[':
+		elements, err := p.parseExpressions(f.Items)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.ArrayLiteral{Token: f.Token, Elements: elements}, nil
+	case '{':
+		if len(f.Items)%2 != 0 {
+			return nil, errorf(f, "hash needs a value for every key")
+		}
+		elements, err := p.parseExpressions(f.Items)
+		if err != nil {
+			return nil, err
+		}
+		hash := &ast.HashLiteral{Token: f.Token, Pairs: []*ast.HashLiteralPair{}}
+		for i := 0; i < len(elements); i += 2 {
+			hash.Pairs = append(hash.Pairs, &ast.HashLiteralPair{Key: elements[i], Value: elements[i+1]})
+		}
+		return hash, nil
+	}
+
+	if len(f.Items) == 0 {
+		return nil, errorf(f, "empty form")
+	}
+	items := f.Items
+	head := f.head()
+
+	switch {
+	case head == "fn":
+		return p.parseFunction(f, 1)
+	case head == "macro":
+		if len(items) < 2 || items[1].Delim != '(' {
+			return nil, errorf(f, "expect (macro (params) body...)")
+		}
+		params, err := parseIdentifiers(items[1].Items)
+		if err != nil {
+			return nil, err
+		}
+		body, err := p.parseBlock(f, items[2:])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.MacroLiteral{Token: keyword(f, "macro"), Parameters: params, Body: body}, nil
+	case head == "if":
+		if len(items) != 3 && len(items) != 4 {
+			return nil, errorf(f, "expect (if cond (do ...) (do ...)?)")
+		}
+		exp := &ast.IfExpression{Token: keyword(f, "if")}
+		var err error
+		if exp.Confition, err = p.parseExpression(items[1]); err != nil {
+			return nil, err
+		}
+		if exp.Consequence, err = p.parseDo(items[2]); err != nil {
+			return nil, err
+		}
+		if len(items) == 4 {
+			if exp.Alternative, err = p.parseDo(items[3]); err != nil {
+				return nil, err
+			}
+		}
+		return exp, nil
+	case head == "try":
+		return p.parseTry(f)
+	case head == "match":
+		return p.parseMatch(f)
+	case head == "yield":
+		if len(items) != 2 {
+			return nil, errorf(f, "expect (yield value)")
+		}
+		if len(p.functions) == 0 {
+			return nil, errorf(f, "yield outside function")
+		}
+		p.functions[len(p.functions)-1].IsGenerator = true
+		value, err := p.parseExpression(items[1])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.YieldExpression{Token: keyword(f, "yield"), Value: value}, nil
+	case head == "index":
+		if len(items) != 3 {
+			return nil, errorf(f, "expect (index value index)")
+		}
+		operands, err := p.parseExpressions(items[1:])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.IndexExpression{Token: tok(f, token.LBRACKET, "["), Left: operands[0], Index: operands[1]}, nil
+	case head == "tuple":
+		elements, err := p.parseExpressions(items[1:])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.TupleLiteral{Token: tok(f, token.LPAREN, "("), Elements: elements}, nil
+	case head == "array-comp" || head == "hash-comp":
+		return p.parseComprehension(f)
+	case head == "call":
+		if len(items) < 2 {
+			return nil, errorf(f, "expect (call function args...)")
+		}
+		return p.parseCall(f, items[1], items[2:])
+	case head == ".":
+		if len(items) != 3 {
+			return nil, errorf(f, "expect (. object property)")
+		}
+		object, err := p.parseExpression(items[1])
+		if err != nil {
+			return nil, err
+		}
+		property, err := parseIdentifier(items[2])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.MemberExpression{Token: tok(f, token.DOT, "."), Object: object, Property: property}, nil
+	case head == "..":
+		if len(items) != 3 && len(items) != 4 {
+			return nil, errorf(f, "expect (.. start end step?)")
+		}
+		operands, err := p.parseExpressions(items[1:])
+		if err != nil {
+			return nil, err
+		}
+		exp := &ast.RangeExpression{Token: tok(f, token.DOTDOT, ".."), Start: operands[0], End: operands[1]}
+		if len(operands) == 3 {
+			exp.Step = operands[2]
+		}
+		return exp, nil
+	case assignOperators[head]:
+		return p.parseAssign(f)
+	case prefixOperators[head] || infixOperators[head]:
+		operands, err := p.parseExpressions(items[1:])
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(operands) == 1 && prefixOperators[head]:
+			return &ast.PrefixExpression{Token: operatorToken(f, head), Operator: head, Right: operands[0]}, nil
+		case len(operands) == 2 && infixOperators[head]:
+			return &ast.InFixExpression{Token: operatorToken(f, head), Operator: head, Left: operands[0], Right: operands[1]}, nil
+		case !infixOperators[head]:
+			return nil, errorf(f, "operator %s takes one operand", head)
+		case !prefixOperators[head]:
+			return nil, errorf(f, "operator %s takes two operands", head)
+		default:
+			return nil, errorf(f, "operator %s takes one or two operands", head)
+		}
+	case head != "" && !specialForms[head] && isOperator(head):
+		return nil, errorf(f, "unknown operator %s", head)
+	case specialForms[head]:
+		return nil, errorf(f, "%s is not an expression", head)
+	}
+
+	return p.parseCall(f, items[0], items[1:])
+}
+
+// (try body... (catch (e) body...) (finally body...))
+func (p *formParser) parseTry(f *form) (ast.Expression, error) {
+	exp := &ast.TryExpression{Token: keyword(f, "try")}
+	items := f.Items[1:]
+
+	body := items
+	for len(body) > 0 {
+		last := body[len(body)-1].head()
+		if last != "catch" && last != "finally" {
+			break
+		}
+		body = body[:len(body)-1]
+	}
+
+	var err error
+	if exp.Block, err = p.parseBlock(f, body); err != nil {
+		return nil, err
+	}
+
+	for _, clause := range items[len(body):] {
+		switch clause.head() {
+		case "catch":
+			if exp.Catch != nil || exp.Finally != nil || len(clause.Items) < 2 || clause.Items[1].Delim != '(' {
+				return nil, errorf(clause, "expect one (catch (name?) body...) before finally")
+			}
+			params, err := parseIdentifiers(clause.Items[1].Items)
+			if err != nil {
+				return nil, err
+			}
+			if len(params) > 1 {
+				return nil, errorf(clause, "catch binds at most one name")
+			}
+			if len(params) == 1 {
+				exp.Parameter = params[0]
+			}
+			if exp.Catch, err = p.parseBlock(clause, clause.Items[2:]); err != nil {
+				return nil, err
+			}
+		case "finally":
+			if exp.Finally != nil {
+				return nil, errorf(clause, "duplicate finally")
+			}
+			if exp.Finally, err = p.parseBlock(clause, clause.Items[1:]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if exp.Catch == nil && exp.Finally == nil {
+		return nil, errorf(f, "try needs a catch or a finally")
+	}
+	return exp, nil
+}
+
+// (match subject (pattern body)...)
+func (p *formParser) parseMatch(f *form) (ast.Expression, error) {
+	if len(f.Items) < 2 {
+		return nil, errorf(f, "expect (match subject (pattern body)...)")
+	}
+	subject, err := p.parseExpression(f.Items[1])
+	if err != nil {
+		return nil, err
+	}
+
+	exp := &ast.MatchExpression{Token: keyword(f, "match"), Subject: subject}
+	for _, arm := range f.Items[2:] {
+		if arm.Delim != '(' || len(arm.Items) != 2 {
+			return nil, errorf(arm, "expect (pattern body)")
+		}
+		operands, err := p.parseExpressions(arm.Items)
+		if err != nil {
+			return nil, err
+		}
+		exp.Arms = append(exp.Arms, &ast.MatchArm{Pattern: operands[0], Body: operands[1]})
+	}
+	return exp, nil
+}
+
+// (array-comp element clauses...) and (hash-comp key value clauses...),
+// where each clause is (for (names) iterable conditions...).
+func (p *formParser) parseComprehension(f *form) (ast.Expression, error) {
+	results := 1
+	if f.head() == "hash-comp" {
+		results = 2
+	}
+	if len(f.Items) < results+2 {
+		return nil, errorf(f, "expect (%s result clauses...)", f.head())
+	}
+
+	values, err := p.parseExpressions(f.Items[1 : results+1])
+	if err != nil {
+		return nil, err
+	}
+
+	clauses := []*ast.ComprehensionClause{}
+	for _, item := range f.Items[results+1:] {
+		if item.head() != "for" || len(item.Items) < 3 || item.Items[1].Delim != '(' {
+			return nil, errorf(item, "expect (for (names) iterable conditions...)")
+		}
+		clause := &ast.ComprehensionClause{Token: keyword(item, "for")}
+		if clause.Variables, err = parseIdentifiers(item.Items[1].Items); err != nil {
+			return nil, err
+		}
+		if len(clause.Variables) < 1 || len(clause.Variables) > 2 {
+			return nil, errorf(item.Items[1], "for binds one or two names")
+		}
+		if clause.Iterable, err = p.parseExpression(item.Items[2]); err != nil {
+			return nil, err
+		}
+		if clause.Conditions, err = p.parseExpressions(item.Items[3:]); err != nil {
+			return nil, err
+		}
+		if len(clause.Conditions) == 0 {
+			clause.Conditions = nil
+		}
+		clauses = append(clauses, clause)
+	}
+
+	if results == 1 {
+		return &ast.ArrayComprehension{Token: tok(f, token.LBRACKET, "["), Element: values[0], Clauses: clauses}, nil
+	}
+	return &ast.HashComprehension{Token: tok(f, token.LBRACE, "{"), Key: values[0], Value: values[1], Clauses: clauses}, nil
+}
+
+// (= name value), (+= (. object field) value), ...
+func (p *formParser) parseAssign(f *form) (ast.Expression, error) {
+	op := f.head()
+	if len(f.Items) != 3 {
+		return nil, errorf(f, "expect (%s target value)", op)
+	}
+	t := tok(f, token.TokenType(op), op)
+	operator := strings.TrimSuffix(op, "=")
+
+	target, err := p.parseExpression(f.Items[1])
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.parseExpression(f.Items[2])
+	if err != nil {
+		return nil, err
+	}
+
+	switch target := target.(type) {
+	case *ast.Identifier:
+		return &ast.AssignExpression{Token: t, Name: target, Operator: operator, Value: value}, nil
+	case *ast.MemberExpression:
+		return &ast.MemberAssignExpression{Token: t, Target: target, Operator: operator, Value: value}, nil
+	default:
+		return nil, errorf(f.Items[1], "cannot assign to %s", target.String())
+	}
+}
+
+// parseCall reads the arguments of a call; :name value passes a keyword argument.
+func (p *formParser) parseCall(f *form, function *form, args []*form) (ast.Expression, error) {
+	fn, err := p.parseExpression(function)
+	if err != nil {
+		return nil, err
+	}
+
+	exp := &ast.CallExpression{Token: tok(f, token.LPAREN, "("), Function: fn, Arguments: []ast.Expression{}}
+	seen := map[string]bool{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !arg.isSymbol() || !strings.HasPrefix(arg.Token.Literal, ":") {
+			if len(seen) > 0 {
+				return nil, errorf(arg, "positional argument after keyword argument")
+			}
+			value, err := p.parseExpression(arg)
+			if err != nil {
+				return nil, err
+			}
+			exp.Arguments = append(exp.Arguments, value)
+			continue
+		}
+
+		nameTok := arg.Token
+		nameTok.Literal = strings.TrimPrefix(nameTok.Literal, ":")
+		name, err := parseIdentifier(&form{Token: nameTok})
+		if err != nil {
+			return nil, err
+		}
+		if i+1 == len(args) {
+			return nil, errorf(arg, "missing value for keyword argument %s", name.Value)
+		}
+		if seen[name.Value] {
+			return nil, errorf(arg, "duplicate keyword argument %s", name.Value)
+		}
+		seen[name.Value] = true
+		i++
+		value, err := p.parseExpression(args[i])
+		if err != nil {
+			return nil, err
+		}
+		exp.Arguments = append(exp.Arguments, &ast.KeywordArgument{Token: name.Token, Name: name, Value: value})
+	}
+	return exp, nil
+}
+
+func (p *formParser) parseExpressions(items []*form) ([]ast.Expression, error) {
+	exps := []ast.Expression{}
+	for _, item := range items {
+		exp, err := p.parseExpression(item)
+		if err != nil {
+			return nil, err
+		}
+		exps = append(exps, exp)
+	}
+	return exps, nil
+}
+
+func parseAtom(f *form) (ast.Expression, error) {
+	switch f.Token.Type {
+	case token.INT:
+		value, _ := strconv.ParseInt(f.Token.Literal, 10, 64)
+		return &ast.IntegerLiteral{Token: f.Token, Value: value}, nil
+	case token.STRING:
+		return &ast.StringLiteral{Token: f.Token, Value: f.Token.Literal}, nil
+	}
+
+	switch f.Token.Literal {
+	case "true":
+		return &ast.Boolean{Token: tok(f, token.TRUE, "true"), Value: true}, nil
+	case "false":
+		return &ast.Boolean{Token: tok(f, token.FALSE, "false"), Value: false}, nil
+	}
+	return parseIdentifier(f)
+}
+
+func parseIdentifier(f *form) (*ast.Identifier, error) {
+	if !f.isSymbol() || !isIdentifier(f.Token.Literal) {
+		return nil, errorf(f, "expect an identifier, got %s", describe(f))
+	}
+	if token.LoopupIdentifier(f.Token.Literal) != token.IDENT {
+		return nil, errorf(f, "%s is a keyword", f.Token.Literal)
+	}
+	return &ast.Identifier{Token: tok(f, token.IDENT, f.Token.Literal), Value: f.Token.Literal}, nil
+}
+
+func parseIdentifiers(items []*form) ([]*ast.Identifier, error) {
+	names := []*ast.Identifier{}
+	for _, item := range items {
+		name, err := parseIdentifier(item)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func isIdentifier(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if !lexer.IsLitter(name[i]) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// operators are symbols that do not start like an identifier
+func isOperator(name string) bool {
+	return !lexer.IsLitter(name[0]) && name[0] != ':'
+}
+
+func describe(f *form) string {
+	if f.isAtom() {
+		return f.Token.Literal
+	}
+	return "a " + string(f.Delim) + " form"
+}
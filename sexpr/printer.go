@@ -0,0 +1,242 @@
+package sexpr
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"com.language/monkey/ast"
+)
+
+// Print renders node as an s-expression that Parse reads back into the same
+// tree. A program prints one top-level form per line. Nodes defined outside
+// the ast package print as (unknown "source"), and nodes missing from a tree
+// that failed to parse as (unknown); Parse rejects both.
+func Print(node ast.Node) string {
+	if node == nil {
+		return list("unknown")
+	}
+	if v := reflect.ValueOf(node); v.Kind() == reflect.Ptr && v.IsNil() {
+		return list("unknown")
+	}
+
+	if program, ok := node.(*ast.Program); ok {
+		forms := []string{}
+		for _, stmt := range program.Statements {
+			forms = append(forms, Print(stmt))
+		}
+		return strings.Join(forms, "\n")
+	}
+
+	switch node := node.(type) {
+	case *ast.ExpressionStatement:
+		return Print(node.Expression)
+	case *ast.LetStatement:
+		if node.Type != nil {
+			return list(node.TokenLiteral(), node.Name.Value, node.Type.Value, Print(node.Value))
+		}
+		return list(node.TokenLiteral(), node.Name.Value, Print(node.Value))
+	case *ast.UnpackStatement:
+		return list(node.TokenLiteral(), names(node.Names), Print(node.Value))
+	case *ast.ReturnStatement:
+		if node.Value == nil {
+			return list("return")
+		}
+		return list("return", Print(node.Value))
+	case *ast.ImportStatement:
+		if node.Alias != nil {
+			return list("import", quote(node.Path.Value), node.Alias.Value)
+		}
+		return list("import", quote(node.Path.Value))
+	case *ast.UseStatement:
+		return list("use", node.Kind.Value, quote(node.Value.Value))
+	case *ast.ExportStatement:
+		return list("export", Print(node.Statement))
+	case *ast.StructStatement:
+		fields := []string{"struct", node.Name.Value}
+		for _, field := range node.Fields {
+			fields = append(fields, field.Value)
+		}
+		return list(fields...)
+	case *ast.EnumStatement:
+		variants := []string{"enum", node.Name.Value}
+		for _, variant := range node.Variants {
+			if variant.Fields == nil {
+				variants = append(variants, variant.Name.Value)
+			} else {
+				variants = append(variants, names(append([]*ast.Identifier{variant.Name}, variant.Fields...)))
+			}
+		}
+		return list(variants...)
+	case *ast.ThrowStatement:
+		return list("throw", Print(node.Value))
+	case *ast.DeferStatement:
+		return list("defer", Print(node.Call))
+	case *ast.ForStatement:
+		return list(append([]string{"for", names(node.Variables), Print(node.Iterable)}, statements(node.Body)...)...)
+	case *ast.ClassStatement:
+		forms := []string{"class", node.Name.Value}
+		if node.Super != nil {
+			forms[1] = list(node.Name.Value, node.Super.Value)
+		}
+		for _, method := range node.Methods {
+			forms = append(forms, Print(method))
+		}
+		return list(forms...)
+	case *ast.FunctionStatement:
+		return function("defn", node.Function)
+	case *ast.BlockStatements:
+		return list(append([]string{"do"}, statements(node)...)...)
+
+	case *ast.Identifier:
+		return node.Value
+	case *ast.IntegerLiteral:
+		return strconv.FormatInt(node.Value, 10)
+	case *ast.StringLiteral:
+		return quote(node.Value)
+	case *ast.Boolean:
+		return strconv.FormatBool(node.Value)
+	case *ast.PrefixExpression:
+		return list(node.Operator, Print(node.Right))
+	case *ast.InFixExpression:
+		return list(node.Operator, Print(node.Left), Print(node.Right))
+	case *ast.AssignExpression:
+		return list(node.Operator+"=", node.Name.Value, Print(node.Value))
+	case *ast.MemberAssignExpression:
+		return list(node.Operator+"=", Print(node.Target), Print(node.Value))
+	case *ast.MemberExpression:
+		return list(".", Print(node.Object), node.Property.Value)
+	case *ast.IndexExpression:
+		return list("index", Print(node.Left), Print(node.Index))
+	case *ast.RangeExpression:
+		if node.Step != nil {
+			return list("..", Print(node.Start), Print(node.End), Print(node.Step))
+		}
+		return list("..", Print(node.Start), Print(node.End))
+	case *ast.ArrayLiteral:
+		return "[" + strings.Join(expressions(node.Elements), " ") + "]"
+	case *ast.HashLiteral:
+		pairs := []string{}
+		for _, pair := range node.Pairs {
+			pairs = append(pairs, Print(pair.Key), Print(pair.Value))
+		}
+		return "{" + strings.Join(pairs, " ") + "}"
+	case *ast.TupleLiteral:
+		return list(append([]string{"tuple"}, expressions(node.Elements)...)...)
+	case *ast.FunctionLiteral:
+		return function("fn", node)
+	case *ast.MacroLiteral:
+		return list(append([]string{"macro", names(node.Parameters)}, statements(node.Body)...)...)
+	case *ast.CallExpression:
+		forms := []string{Print(node.Function)}
+		if ident, ok := node.Function.(*ast.Identifier); ok && specialForms[ident.Value] {
+			forms = []string{"call", ident.Value}
+		}
+		return list(append(forms, expressions(node.Arguments)...)...)
+	case *ast.KeywordArgument:
+		return ":" + node.Name.Value + " " + Print(node.Value)
+	case *ast.IfExpression:
+		if node.Alternative != nil {
+			return list("if", Print(node.Confition), Print(node.Consequence), Print(node.Alternative))
+		}
+		return list("if", Print(node.Confition), Print(node.Consequence))
+	case *ast.TryExpression:
+		forms := append([]string{"try"}, statements(node.Block)...)
+		if node.Catch != nil {
+			param := "()"
+			if node.Parameter != nil {
+				param = "(" + node.Parameter.Value + ")"
+			}
+			forms = append(forms, list(append([]string{"catch", param}, statements(node.Catch)...)...))
+		}
+		if node.Finally != nil {
+			forms = append(forms, list(append([]string{"finally"}, statements(node.Finally)...)...))
+		}
+		return list(forms...)
+	case *ast.MatchExpression:
+		forms := []string{"match", Print(node.Subject)}
+		for _, arm := range node.Arms {
+			forms = append(forms, list(Print(arm.Pattern), Print(arm.Body)))
+		}
+		return list(forms...)
+	case *ast.YieldExpression:
+		return list("yield", Print(node.Value))
+	case *ast.ArrayComprehension:
+		return list(append([]string{"array-comp", Print(node.Element)}, clauses(node.Clauses)...)...)
+	case *ast.HashComprehension:
+		return list(append([]string{"hash-comp", Print(node.Key), Print(node.Value)}, clauses(node.Clauses)...)...)
+	}
+
+	return list("unknown", quote(node.String()))
+}
+
+func list(items ...string) string {
+	return "(" + strings.Join(items, " ") + ")"
+}
+
+func names(idents []*ast.Identifier) string {
+	values := []string{}
+	for _, ident := range idents {
+		values = append(values, ident.Value)
+	}
+	return list(values...)
+}
+
+func expressions(exps []ast.Expression) []string {
+	forms := []string{}
+	for _, exp := range exps {
+		forms = append(forms, Print(exp))
+	}
+	return forms
+}
+
+func statements(block *ast.BlockStatements) []string {
+	forms := []string{}
+	for _, stmt := range block.Statements {
+		forms = append(forms, Print(stmt))
+	}
+	return forms
+}
+
+// function prints (head name? (params) options... body...).
+func function(head string, fn *ast.FunctionLiteral) string {
+	forms := []string{head}
+	if fn.Name != nil {
+		forms = append(forms, fn.Name.Value)
+	}
+
+	params := []string{}
+	for i, param := range fn.Parameters {
+		if i < len(fn.ParameterTypes) && fn.ParameterTypes[i] != nil {
+			params = append(params, list(param.Value, fn.ParameterTypes[i].Value))
+		} else {
+			params = append(params, param.Value)
+		}
+	}
+	forms = append(forms, list(params...))
+
+	if fn.ReturnType != nil {
+		forms = append(forms, list("->", fn.ReturnType.Value))
+	}
+	for _, clause := range fn.Requires {
+		forms = append(forms, list("requires", Print(clause)))
+	}
+	for _, clause := range fn.Ensures {
+		forms = append(forms, list("ensures", Print(clause)))
+	}
+
+	return list(append(forms, statements(fn.Body)...)...)
+}
+
+func clauses(clauses []*ast.ComprehensionClause) []string {
+	forms := []string{}
+	for _, clause := range clauses {
+		forms = append(forms, list(append([]string{"for", names(clause.Variables), Print(clause.Iterable)}, expressions(clause.Conditions)...)...))
+	}
+	return forms
+}
+
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
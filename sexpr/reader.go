@@ -0,0 +1,170 @@
+package sexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"com.language/monkey/token"
+)
+
+// form is one datum of the input: an atom, a (list), an [array] or a {hash}.
+type form struct {
+	// position of the atom or of the opening delimiter
+	Token token.Token
+	// 0 for atoms, otherwise '(', '[' or '{'
+	Delim byte
+	Items []*form
+}
+
+func (f *form) isAtom() bool {
+	return f.Delim == 0
+}
+
+// isSymbol reports whether f is an atom other than a string or integer literal.
+func (f *form) isSymbol() bool {
+	return f.isAtom() && f.Token.Type == token.IDENT
+}
+
+// head returns the symbol a list starts with, or "".
+func (f *form) head() string {
+	if f.Delim != '(' || len(f.Items) == 0 || !f.Items[0].isSymbol() {
+		return ""
+	}
+	return f.Items[0].Token.Literal
+}
+
+type reader struct {
+	input  []rune
+	pos    int
+	line   int
+	column int
+}
+
+// read splits input into its top-level forms.
+func read(input string) ([]*form, error) {
+	r := &reader{input: []rune(input), line: 1, column: 1}
+
+	forms := []*form{}
+	for {
+		r.skipSpace()
+		if r.pos >= len(r.input) {
+			return forms, nil
+		}
+		f, err := r.readForm()
+		if err != nil {
+			return nil, err
+		}
+		forms = append(forms, f)
+	}
+}
+
+func (r *reader) advance() rune {
+	ch := r.input[r.pos]
+	r.pos++
+	if ch == '\n' {
+		r.line++
+		r.column = 1
+	} else {
+		r.column++
+	}
+	return ch
+}
+
+// skipSpace skips whitespace and ; comments.
+func (r *reader) skipSpace() {
+	for r.pos < len(r.input) {
+		ch := r.input[r.pos]
+		switch {
+		case ch == ';':
+			for r.pos < len(r.input) && r.input[r.pos] != '\n' {
+				r.advance()
+			}
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			r.advance()
+		default:
+			return
+		}
+	}
+}
+
+func (r *reader) readForm() (*form, error) {
+	tok := token.Token{Line: r.line, Column: r.column}
+	ch := r.input[r.pos]
+
+	switch ch {
+	case '(', '[', '{':
+		r.advance()
+		tok.Type = token.TokenType(string(ch))
+		tok.Literal = string(ch)
+		f := &form{Token: tok, Delim: byte(ch)}
+		end := map[rune]rune{'(': ')', '[': ']', '{': '}'}[ch]
+		for {
+			r.skipSpace()
+			if r.pos >= len(r.input) {
+				return nil, fmt.Errorf("line %d, column %d: unclosed %c", tok.Line, tok.Column, ch)
+			}
+			if r.input[r.pos] == end {
+				r.advance()
+				return f, nil
+			}
+			item, err := r.readForm()
+			if err != nil {
+				return nil, err
+			}
+			f.Items = append(f.Items, item)
+		}
+	case ')', ']', '}':
+		return nil, fmt.Errorf("line %d, column %d: unexpected %c", tok.Line, tok.Column, ch)
+	case '"':
+		return r.readString(tok)
+	}
+
+	start := r.pos
+	for r.pos < len(r.input) && !isDelimiter(r.input[r.pos]) {
+		r.advance()
+	}
+	tok.Literal = string(r.input[start:r.pos])
+	if _, err := strconv.ParseInt(tok.Literal, 10, 64); err == nil {
+		tok.Type = token.INT
+	} else {
+		tok.Type = token.IDENT
+	}
+	return &form{Token: tok}, nil
+}
+
+func (r *reader) readString(tok token.Token) (*form, error) {
+	var out strings.Builder
+	r.advance()
+
+	for {
+		if r.pos >= len(r.input) {
+			return nil, fmt.Errorf("line %d, column %d: unterminated string", tok.Line, tok.Column)
+		}
+		ch := r.advance()
+		switch ch {
+		case '"':
+			tok.Type = token.STRING
+			tok.Literal = out.String()
+			return &form{Token: tok}, nil
+		case '\\':
+			if r.pos >= len(r.input) {
+				continue
+			}
+			switch esc := r.advance(); esc {
+			case 'n':
+				out.WriteRune('\n')
+			case 't':
+				out.WriteRune('\t')
+			default:
+				out.WriteRune(esc)
+			}
+		default:
+			out.WriteRune(ch)
+		}
+	}
+}
+
+func isDelimiter(ch rune) bool {
+	return strings.ContainsRune(" \t\r\n()[]{}\";", ch)
+}
@@ -0,0 +1,202 @@
+package sexpr
+
+import (
+	"strings"
+	"testing"
+
+	"com.language/monkey/ast"
+	"com.language/monkey/evaluator"
+	"com.language/monkey/lexer"
+	"com.language/monkey/object"
+	"com.language/monkey/parser"
+)
+
+func parseMonkey(t *testing.T, input string) *ast.Program {
+	p := parser.New(lexer.New(input))
+	program := p.ParserProgram()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("%s: parser errors %v", input, p.Errors())
+	}
+	return program
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{`(let x (+ 1 2))`, "let x = (1 + 2);"},
+		{`(const n int 5)`, "const n: int = 5;"},
+		{`(let (q r) (divmod 7 2))`, "let (q, r) = divmod(7, 2);"},
+		{`(- (! true))`, "(-(!true))"},
+		{`(* (+ a b) c)`, "((a + b) * c)"},
+		{`(return)`, "return ;"},
+		{`["a" [1 2] {"k" 1}]`, "[a, [1, 2], {k:1}]"},
+		{`(index xs 0) (. p x) (.. 0 10 2) (tuple 1 2)`, "(xs[0])(p.x)(0..10 step 2)(1, 2)"},
+		{`(= x 1) (+= (. self n) 1)`, "(x = 1)(self.n += 1)"},
+		{`(fn ((x int) y) (-> int) (requires (> x 0)) (+ x y))`, "fn(x: int, y) -> int  requires (x > 0) (x + y)"},
+		{`(defn add (a b) (return (+ a b)))`, "fn add(a, b)return (a + b);"},
+		{`(if (< x 1) (do 1) (do 2))`, "if(x < 1) 12"},
+		{`(request url :timeout 30 :retry true)`, "request(url, timeout: 30, retry: true)"},
+		{`(call index xs)`, "index(xs)"},
+		{`(array-comp (* x x) (for (x) xs (> x 1)))`, "[(x * x) for x in xs if (x > 1)]"},
+		{`(struct Point x y) (enum Shape Empty (Circle r))`, "struct Point { x, y }enum Shape { Empty, Circle(r) }"},
+		{"; a comment\n(puts \"say \\\"hi\\\"\")", `puts(say "hi")`},
+	}
+
+	for _, itm := range tests {
+		program, err := Parse(itm.input)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", itm.input, err)
+			continue
+		}
+		if program.String() != itm.expect {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expect, program.String())
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"let x = 1 + 2 * 3;", "(let x (+ 1 (* 2 3)))"},
+		{"fn(x) { x }(5)", "((fn (x) x) 5)"},
+		{"-a", "(- a)"},
+		{"a.b(1)", "((. a b) 1)"},
+		{`{"a": [1, 2]}`, `{"a" [1 2]}`},
+		{"let (x, y) = (1, 2)", "(let (x y) (tuple 1 2))"},
+		{"try { f() } catch (e) { g(e) } finally { h() }", "(try (f) (catch (e) (g e)) (finally (h)))"},
+		{"match (x) { 1 => a, _ => b }", "(match x (1 a) (_ b))"},
+		{"class B < A { init(n) { self.n = n } }", "(class (B A) (fn init (n) (= (. self n) n)))"},
+		{"tuple(1)", "(call tuple 1)"},
+		{"f(x, k: 1)", "(f x :k 1)"},
+		{"let a = 1\nlet b = 2", "(let a 1)\n(let b 2)"},
+	}
+
+	for _, itm := range tests {
+		printed := Print(parseMonkey(t, itm.input))
+		if printed != itm.expect {
+			t.Errorf("%s: expect %q, got %q", itm.input, itm.expect, printed)
+		}
+	}
+}
+
+func TestPrintIncomplete(t *testing.T) {
+	p := parser.New(lexer.New("let x = ; f(1, )"))
+	printed := Print(p.ParserProgram())
+	if len(p.Errors()) == 0 {
+		t.Fatalf("expect parser errors")
+	}
+	if !strings.Contains(printed, "(unknown)") {
+		t.Errorf("expect missing nodes printed as (unknown), got %q", printed)
+	}
+
+	var missing *ast.Identifier
+	if printed := Print(missing); printed != "(unknown)" {
+		t.Errorf("expect (unknown), got %q", printed)
+	}
+	if _, err := Parse("(unknown)"); err == nil {
+		t.Errorf("expect (unknown) to be rejected")
+	}
+}
+
+// every program must survive Monkey -> s-expression -> ast unchanged
+func TestRoundTrip(t *testing.T) {
+	inputs := []string{
+		`let add = fn(a: int, b: int) -> int requires a >= 0 ensures result >= a { a + b }; add(1, 2)`,
+		`const greeting: string = "hello"; export let x = 1`,
+		`import "lib/math.mk" as math; math.sqrt(4)`,
+		`fn fib(n) { if (n < 2) { return n } fib(n - 1) + fib(n - 2) }`,
+		`fn gen() { yield 1; yield 2 }; for x in gen() { puts(x) }`,
+		`for k, v in {"a": 1} { puts(k, v) }`,
+		`struct P { x, y }; enum E { A, B(n) }; match (E.B(1)) { E.B(n) => n, _ => 0 }`,
+		`class Counter { init() { self.n = 0 } inc(by) { self.n += by; self } }; Counter().inc(2).n`,
+		`class Loud < Counter { inc(by) { super.inc(by * 10) } }`,
+		`let r = try { throw "x" } catch { 1 } finally { defer f() }`,
+		`let xs = [x * y for x in 0..3 for y in [1, 2] if x > 0]; {k: v for k, v in h if v}`,
+		`let m = macro(a, b) { quote(unquote(a) + unquote(b)) }; m(1, 2)`,
+		`let t = (1, "two", (3,)); let (a, b, c) = t; t[0] == a != false`,
+		`x = 1; x *= 2; y -= 3; z /= 4; !(-x) in [1]; request(url, timeout: 30)`,
+		`fn() { return }; fn() { }`,
+		`let s = "back\slash"; index(s)`,
+		`use edition "2024"; use feature "tuples"; let p = (1, 2)`,
+	}
+
+	for _, input := range inputs {
+		program := parseMonkey(t, input)
+		printed := Print(program)
+
+		reparsed, err := Parse(printed)
+		if err != nil {
+			t.Errorf("%s: cannot parse %s: %v", input, printed, err)
+			continue
+		}
+		if reparsed.String() != program.String() {
+			t.Errorf("%s: expect %q, got %q (via %s)", input, program.String(), reparsed.String(), printed)
+		}
+		if again := Print(reparsed); again != printed {
+			t.Errorf("%s: printed %q, then %q", input, printed, again)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{`(let x`, "line 1, column 1: unclosed ("},
+		{`)`, "line 1, column 1: unexpected )"},
+		{`"abc`, "line 1, column 1: unterminated string"},
+		{`()`, "line 1, column 1: empty form"},
+		{`(let 1 2)`, "line 1, column 6: expect an identifier, got 1"},
+		{"(let x 1)\n  (fn (if) x)", "line 2, column 8: if is a keyword"},
+		{`{1 2 3}`, "line 1, column 1: hash needs a value for every key"},
+		{`(f :k)`, "line 1, column 4: missing value for keyword argument k"},
+		{`(yield 1)`, "line 1, column 1: yield outside function"},
+		{`(fn () (ensures true) (yield 1))`, "line 1, column 1: ensures clause on a generator function"},
+		{`(+ 1 2 3)`, "line 1, column 1: operator + takes two operands"},
+		{`(- 1 2 3)`, "line 1, column 1: operator - takes one or two operands"},
+		{`(! a b)`, "line 1, column 1: operator ! takes one operand"},
+		{`(in x)`, "line 1, column 1: operator in takes two operands"},
+		{`(|> a b)`, "line 1, column 1: unknown operator |>"},
+		{`(f :k 1 2)`, "line 1, column 9: positional argument after keyword argument"},
+		{`(f :k 1 :k 2)`, "line 1, column 9: duplicate keyword argument k"},
+		{`(= (f) 1)`, "line 1, column 4: cannot assign to f()"},
+		{`(try 1)`, "line 1, column 1: try needs a catch or a finally"},
+		{`(f (let x 1))`, "line 1, column 4: let is not an expression"},
+		{`(unknown "a |> b")`, "line 1, column 1: unknown is not an expression"},
+	}
+
+	for _, itm := range tests {
+		_, err := Parse(itm.input)
+		if err == nil || err.Error() != itm.expect {
+			t.Errorf("%s: expect error %q, got %v", itm.input, itm.expect, err)
+		}
+	}
+}
+
+func TestEvalParsed(t *testing.T) {
+	input := `
+(defn fact (n) (requires (>= n 0))
+  (if (< n 2) (do 1) (do (* n (fact (- n 1))))))
+(class Acc
+  (fn init () (= (. self total) 0))
+  (fn add (n) (+= (. self total) n) self))
+(let acc (Acc))
+(for (i) (.. 1 5) ((. acc add) (fact i)))
+(let (a b) (tuple (. acc total) (len [1 2 3])))
+[a b]`
+
+	program, err := Parse(input)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	result := evaluator.Eval(program, object.NewEnvironment())
+	if result == nil || strings.TrimSpace(result.Inspect()) != "[33, 3]" {
+		t.Errorf("expect [33, 3], got %+v", result)
+	}
+}
@@ -20,10 +20,19 @@ const (
 	NOTEQUAL = "!="
 	LEQ      = "<="
 	GEQ      = ">="
+	FATARROW = "=>"
+	ARROW    = "->"
+
+	PLUS_ASSIGN     = "+="
+	MINUS_ASSIGN    = "-="
+	ASTERISK_ASSIGN = "*="
+	SLASH_ASSIGN    = "/="
 
 	COMMA     = ","
 	SEMICOLON = ";"
 	COLON     = ":"
+	DOT       = "."
+	DOTDOT    = ".."
 
 	LPAREN   = "("
 	RPAREN   = ")"
@@ -39,6 +48,26 @@ const (
 	RETURN   = "RETURN"
 	TRUE     = "TRUE"
 	FALSE    = "FALSE"
+	MACRO    = "MACRO"
+	IMPORT   = "IMPORT"
+	EXPORT   = "EXPORT"
+	AS       = "AS"
+	CONST    = "CONST"
+	STRUCT   = "STRUCT"
+	ENUM     = "ENUM"
+	MATCH    = "MATCH"
+	THROW    = "THROW"
+	TRY      = "TRY"
+	CATCH    = "CATCH"
+	FINALLY  = "FINALLY"
+	DEFER    = "DEFER"
+	YIELD    = "YIELD"
+	FOR      = "FOR"
+	IN       = "IN"
+	CLASS    = "CLASS"
+	REQUIRES = "REQUIRES"
+	ENSURES  = "ENSURES"
+	USE      = "USE"
 )
 
 type TokenType string
@@ -46,16 +75,41 @@ type TokenType string
 type Token struct {
 	Type    TokenType
 	Literal string
+	// position of the first character, both 1-based
+	Line   int
+	Column int
+	// a line break separates the token from the previous one
+	NewlineBefore bool
 }
 
 var keyworkds = map[string]TokenType{
-	"fn":     FUNCTION,
-	"let":    LET,
-	"if":     IF,
-	"else":   ELSE,
-	"return": RETURN,
-	"true":   TRUE,
-	"false":  FALSE,
+	"fn":       FUNCTION,
+	"let":      LET,
+	"if":       IF,
+	"else":     ELSE,
+	"return":   RETURN,
+	"true":     TRUE,
+	"false":    FALSE,
+	"macro":    MACRO,
+	"import":   IMPORT,
+	"export":   EXPORT,
+	"as":       AS,
+	"const":    CONST,
+	"struct":   STRUCT,
+	"enum":     ENUM,
+	"match":    MATCH,
+	"throw":    THROW,
+	"try":      TRY,
+	"catch":    CATCH,
+	"finally":  FINALLY,
+	"defer":    DEFER,
+	"yield":    YIELD,
+	"for":      FOR,
+	"in":       IN,
+	"class":    CLASS,
+	"requires": REQUIRES,
+	"ensures":  ENSURES,
+	"use":      USE,
 }
 
 func LoopupIdentifier(ident string) TokenType {